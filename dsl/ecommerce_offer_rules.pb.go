@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -101,19 +102,35 @@ const (
 	ValueType_FLOAT_VAL              ValueType = 5
 	ValueType_DOUBLE_VAL             ValueType = 6
 	ValueType_STRING_LIST_VAL        ValueType = 7
+	ValueType_DECIMAL_VAL            ValueType = 8
+	ValueType_RANGE_VAL              ValueType = 9
+	ValueType_FIELD_REF_VAL          ValueType = 10
+	ValueType_ARITHMETIC_VAL         ValueType = 11
+	ValueType_INT_LIST_VAL           ValueType = 12
+	ValueType_FLOAT_LIST_VAL         ValueType = 13
+	ValueType_LONG_LIST_VAL          ValueType = 14
+	ValueType_DOUBLE_LIST_VAL        ValueType = 15
 )
 
 // Enum value maps for ValueType.
 var (
 	ValueType_name = map[int32]string{
-		0: "VALUE_TYPE_UNSPECIFIED",
-		1: "STRING_VAL",
-		2: "BOOL_VAL",
-		3: "INTEGER_VAL",
-		4: "LONG_VAL",
-		5: "FLOAT_VAL",
-		6: "DOUBLE_VAL",
-		7: "STRING_LIST_VAL",
+		0:  "VALUE_TYPE_UNSPECIFIED",
+		1:  "STRING_VAL",
+		2:  "BOOL_VAL",
+		3:  "INTEGER_VAL",
+		4:  "LONG_VAL",
+		5:  "FLOAT_VAL",
+		6:  "DOUBLE_VAL",
+		7:  "STRING_LIST_VAL",
+		8:  "DECIMAL_VAL",
+		9:  "RANGE_VAL",
+		10: "FIELD_REF_VAL",
+		11: "ARITHMETIC_VAL",
+		12: "INT_LIST_VAL",
+		13: "FLOAT_LIST_VAL",
+		14: "LONG_LIST_VAL",
+		15: "DOUBLE_LIST_VAL",
 	}
 	ValueType_value = map[string]int32{
 		"VALUE_TYPE_UNSPECIFIED": 0,
@@ -124,6 +141,14 @@ var (
 		"FLOAT_VAL":              5,
 		"DOUBLE_VAL":             6,
 		"STRING_LIST_VAL":        7,
+		"DECIMAL_VAL":            8,
+		"RANGE_VAL":              9,
+		"FIELD_REF_VAL":          10,
+		"ARITHMETIC_VAL":         11,
+		"INT_LIST_VAL":           12,
+		"FLOAT_LIST_VAL":         13,
+		"LONG_LIST_VAL":          14,
+		"DOUBLE_LIST_VAL":        15,
 	}
 )
 
@@ -154,7 +179,8 @@ func (ValueType) EnumDescriptor() ([]byte, []int) {
 	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1}
 }
 
-// Operators used in the GRule expressions.
+// Operators used in the GRule expressions. The grl_operand_types annotation is
+// the compatibility matrix of operators and input field types.
 type GRuleExpressionOperator int32
 
 const (
@@ -165,20 +191,56 @@ const (
 	GRuleExpressionOperator_GREATER_THAN_EQUALS             GRuleExpressionOperator = 4
 	GRuleExpressionOperator_EQUALS                          GRuleExpressionOperator = 5
 	GRuleExpressionOperator_NOT_EQUALS                      GRuleExpressionOperator = 6
-	GRuleExpressionOperator_HAS_CATEGORY_FUNCTION           GRuleExpressionOperator = 7 // Refer: https://github.com/hyperjumptech/grule-rule-engine/blob/master/docs/en/Function_en.md#stringinstring--bool
+	GRuleExpressionOperator_HAS_CATEGORY_FUNCTION           GRuleExpressionOperator = 7 // Refer: https://github.com/hyperjumptech/grule-rule-engine/blob/master/docs/en/Function_en.md
+	// String operators map onto grule's built-in string functions.
+	GRuleExpressionOperator_CONTAINS    GRuleExpressionOperator = 8
+	GRuleExpressionOperator_STARTS_WITH GRuleExpressionOperator = 9
+	GRuleExpressionOperator_ENDS_WITH   GRuleExpressionOperator = 10
+	// The value is a Go (RE2) regular expression, validated when the rule is converted to GRL.
+	GRuleExpressionOperator_MATCHES_REGEX GRuleExpressionOperator = 11
+	// The value is lower-cased when the rule is converted to GRL.
+	GRuleExpressionOperator_EQUALS_IGNORE_CASE GRuleExpressionOperator = 12
+	// Set membership, the value is a list of candidates. Helper is grl.Helpers,
+	// which must be added to the data context.
+	GRuleExpressionOperator_IN     GRuleExpressionOperator = 13
+	GRuleExpressionOperator_NOT_IN GRuleExpressionOperator = 14
+	// HAS_CATEGORY_FUNCTION matches when the list field holds any of the
+	// categories, these when it holds all of them or none of them.
+	GRuleExpressionOperator_HAS_ALL_CATEGORIES GRuleExpressionOperator = 15
+	GRuleExpressionOperator_HAS_NO_CATEGORIES  GRuleExpressionOperator = 16
+	// Emptiness tests of list fields, they take no value.
+	GRuleExpressionOperator_IS_EMPTY     GRuleExpressionOperator = 17
+	GRuleExpressionOperator_IS_NOT_EMPTY GRuleExpressionOperator = 18
+	// Range tests, the value is a Range. They are written as the two bound
+	// comparisons joined by the grl_operator, e.g. ( :field >= lower && :field <= upper ).
+	GRuleExpressionOperator_BETWEEN     GRuleExpressionOperator = 19
+	GRuleExpressionOperator_NOT_BETWEEN GRuleExpressionOperator = 20
 )
 
 // Enum value maps for GRuleExpressionOperator.
 var (
 	GRuleExpressionOperator_name = map[int32]string{
-		0: "EXPRESSION_OPERATOR_UNSPECIFIED",
-		1: "LESS_THAN",
-		2: "LESS_THAN_EQUALS",
-		3: "GREATER_THAN",
-		4: "GREATER_THAN_EQUALS",
-		5: "EQUALS",
-		6: "NOT_EQUALS",
-		7: "HAS_CATEGORY_FUNCTION",
+		0:  "EXPRESSION_OPERATOR_UNSPECIFIED",
+		1:  "LESS_THAN",
+		2:  "LESS_THAN_EQUALS",
+		3:  "GREATER_THAN",
+		4:  "GREATER_THAN_EQUALS",
+		5:  "EQUALS",
+		6:  "NOT_EQUALS",
+		7:  "HAS_CATEGORY_FUNCTION",
+		8:  "CONTAINS",
+		9:  "STARTS_WITH",
+		10: "ENDS_WITH",
+		11: "MATCHES_REGEX",
+		12: "EQUALS_IGNORE_CASE",
+		13: "IN",
+		14: "NOT_IN",
+		15: "HAS_ALL_CATEGORIES",
+		16: "HAS_NO_CATEGORIES",
+		17: "IS_EMPTY",
+		18: "IS_NOT_EMPTY",
+		19: "BETWEEN",
+		20: "NOT_BETWEEN",
 	}
 	GRuleExpressionOperator_value = map[string]int32{
 		"EXPRESSION_OPERATOR_UNSPECIFIED": 0,
@@ -189,6 +251,19 @@ var (
 		"EQUALS":                          5,
 		"NOT_EQUALS":                      6,
 		"HAS_CATEGORY_FUNCTION":           7,
+		"CONTAINS":                        8,
+		"STARTS_WITH":                     9,
+		"ENDS_WITH":                       10,
+		"MATCHES_REGEX":                   11,
+		"EQUALS_IGNORE_CASE":              12,
+		"IN":                              13,
+		"NOT_IN":                          14,
+		"HAS_ALL_CATEGORIES":              15,
+		"HAS_NO_CATEGORIES":               16,
+		"IS_EMPTY":                        17,
+		"IS_NOT_EMPTY":                    18,
+		"BETWEEN":                         19,
+		"NOT_BETWEEN":                     20,
 	}
 )
 
@@ -219,6 +294,55 @@ func (GRuleExpressionOperator) EnumDescriptor() ([]byte, []int) {
 	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{2}
 }
 
+// Aggregates computed from a list field before it is compared. The
+// grl_field_type annotation is the type of the aggregate, which the operator
+// and value of the expression are checked against.
+type GRuleAggregate int32
+
+const (
+	GRuleAggregate_AGGREGATE_UNSPECIFIED GRuleAggregate = 0
+	GRuleAggregate_COUNT                 GRuleAggregate = 1
+)
+
+// Enum value maps for GRuleAggregate.
+var (
+	GRuleAggregate_name = map[int32]string{
+		0: "AGGREGATE_UNSPECIFIED",
+		1: "COUNT",
+	}
+	GRuleAggregate_value = map[string]int32{
+		"AGGREGATE_UNSPECIFIED": 0,
+		"COUNT":                 1,
+	}
+)
+
+func (x GRuleAggregate) Enum() *GRuleAggregate {
+	p := new(GRuleAggregate)
+	*p = x
+	return p
+}
+
+func (x GRuleAggregate) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GRuleAggregate) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[3].Descriptor()
+}
+
+func (GRuleAggregate) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[3]
+}
+
+func (x GRuleAggregate) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GRuleAggregate.Descriptor instead.
+func (GRuleAggregate) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{3}
+}
+
 // Operators used in the GRule conditions and expressions
 // to join multiple conditions or expressions.
 type GRuleJoinOperator int32
@@ -254,11 +378,11 @@ func (x GRuleJoinOperator) String() string {
 }
 
 func (GRuleJoinOperator) Descriptor() protoreflect.EnumDescriptor {
-	return file_ecommerce_offer_rules_proto_enumTypes[3].Descriptor()
+	return file_ecommerce_offer_rules_proto_enumTypes[4].Descriptor()
 }
 
 func (GRuleJoinOperator) Type() protoreflect.EnumType {
-	return &file_ecommerce_offer_rules_proto_enumTypes[3]
+	return &file_ecommerce_offer_rules_proto_enumTypes[4]
 }
 
 func (x GRuleJoinOperator) Number() protoreflect.EnumNumber {
@@ -267,7 +391,191 @@ func (x GRuleJoinOperator) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GRuleJoinOperator.Descriptor instead.
 func (GRuleJoinOperator) EnumDescriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{3}
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{4}
+}
+
+// Operators of an Arithmetic value. The binary operators are written between
+// the operands, MIN and MAX call their grl_operator template. Helper is
+// grl.Helpers, which must be added to the data context.
+type ArithmeticOperator int32
+
+const (
+	ArithmeticOperator_ARITHMETIC_OPERATOR_UNSPECIFIED ArithmeticOperator = 0
+	ArithmeticOperator_ADD                             ArithmeticOperator = 1
+	ArithmeticOperator_SUBTRACT                        ArithmeticOperator = 2
+	ArithmeticOperator_MULTIPLY                        ArithmeticOperator = 3
+	ArithmeticOperator_DIVIDE                          ArithmeticOperator = 4
+	ArithmeticOperator_MIN                             ArithmeticOperator = 5
+	ArithmeticOperator_MAX                             ArithmeticOperator = 6
+)
+
+// Enum value maps for ArithmeticOperator.
+var (
+	ArithmeticOperator_name = map[int32]string{
+		0: "ARITHMETIC_OPERATOR_UNSPECIFIED",
+		1: "ADD",
+		2: "SUBTRACT",
+		3: "MULTIPLY",
+		4: "DIVIDE",
+		5: "MIN",
+		6: "MAX",
+	}
+	ArithmeticOperator_value = map[string]int32{
+		"ARITHMETIC_OPERATOR_UNSPECIFIED": 0,
+		"ADD":                             1,
+		"SUBTRACT":                        2,
+		"MULTIPLY":                        3,
+		"DIVIDE":                          4,
+		"MIN":                             5,
+		"MAX":                             6,
+	}
+)
+
+func (x ArithmeticOperator) Enum() *ArithmeticOperator {
+	p := new(ArithmeticOperator)
+	*p = x
+	return p
+}
+
+func (x ArithmeticOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArithmeticOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[5].Descriptor()
+}
+
+func (ArithmeticOperator) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[5]
+}
+
+func (x ArithmeticOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArithmeticOperator.Descriptor instead.
+func (ArithmeticOperator) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{5}
+}
+
+// How a rule ends after its actions ran.
+type GRuleTerminationMode int32
+
+const (
+	// Retract("<rule name>"), the rule fires at most once per evaluation.
+	GRuleTerminationMode_RETRACT_SELF GRuleTerminationMode = 0
+	// The rule is not retracted and calls Changed(...) on the fields it assigned,
+	// so it and the rules reading them are evaluated again in the next cycle. Its
+	// conditions must stop matching, or the engine runs into its cycle limit.
+	GRuleTerminationMode_REFIRE GRuleTerminationMode = 1
+	// Complete(), the engine stops evaluating any further rule.
+	GRuleTerminationMode_COMPLETE_ENGINE GRuleTerminationMode = 2
+)
+
+// Enum value maps for GRuleTerminationMode.
+var (
+	GRuleTerminationMode_name = map[int32]string{
+		0: "RETRACT_SELF",
+		1: "REFIRE",
+		2: "COMPLETE_ENGINE",
+	}
+	GRuleTerminationMode_value = map[string]int32{
+		"RETRACT_SELF":    0,
+		"REFIRE":          1,
+		"COMPLETE_ENGINE": 2,
+	}
+)
+
+func (x GRuleTerminationMode) Enum() *GRuleTerminationMode {
+	p := new(GRuleTerminationMode)
+	*p = x
+	return p
+}
+
+func (x GRuleTerminationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GRuleTerminationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[6].Descriptor()
+}
+
+func (GRuleTerminationMode) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[6]
+}
+
+func (x GRuleTerminationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GRuleTerminationMode.Descriptor instead.
+func (GRuleTerminationMode) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{6}
+}
+
+// Days of the week a Schedule applies on.
+type Weekday int32
+
+const (
+	Weekday_WEEKDAY_UNSPECIFIED Weekday = 0
+	Weekday_MONDAY              Weekday = 1
+	Weekday_TUESDAY             Weekday = 2
+	Weekday_WEDNESDAY           Weekday = 3
+	Weekday_THURSDAY            Weekday = 4
+	Weekday_FRIDAY              Weekday = 5
+	Weekday_SATURDAY            Weekday = 6
+	Weekday_SUNDAY              Weekday = 7
+)
+
+// Enum value maps for Weekday.
+var (
+	Weekday_name = map[int32]string{
+		0: "WEEKDAY_UNSPECIFIED",
+		1: "MONDAY",
+		2: "TUESDAY",
+		3: "WEDNESDAY",
+		4: "THURSDAY",
+		5: "FRIDAY",
+		6: "SATURDAY",
+		7: "SUNDAY",
+	}
+	Weekday_value = map[string]int32{
+		"WEEKDAY_UNSPECIFIED": 0,
+		"MONDAY":              1,
+		"TUESDAY":             2,
+		"WEDNESDAY":           3,
+		"THURSDAY":            4,
+		"FRIDAY":              5,
+		"SATURDAY":            6,
+		"SUNDAY":              7,
+	}
+)
+
+func (x Weekday) Enum() *Weekday {
+	p := new(Weekday)
+	*p = x
+	return p
+}
+
+func (x Weekday) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Weekday) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[7].Descriptor()
+}
+
+func (Weekday) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[7]
+}
+
+func (x Weekday) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Weekday.Descriptor instead.
+func (Weekday) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{7}
 }
 
 // Represents the input field to be tested.
@@ -291,6 +599,7 @@ const (
 	EcommerceOfferRule_Condition_RETURN_RATE_PERCENT         EcommerceOfferRule_Condition_InputField = 14
 	EcommerceOfferRule_Condition_HAS_COUPON_REDEEMED_BEFORE  EcommerceOfferRule_Condition_InputField = 15
 	EcommerceOfferRule_Condition_SIGNUP_DAYS_AGO             EcommerceOfferRule_Condition_InputField = 16
+	EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS     EcommerceOfferRule_Condition_InputField = 17
 )
 
 // Enum value maps for EcommerceOfferRule_Condition_InputField.
@@ -313,6 +622,7 @@ var (
 		14: "RETURN_RATE_PERCENT",
 		15: "HAS_COUPON_REDEEMED_BEFORE",
 		16: "SIGNUP_DAYS_AGO",
+		17: "LIFETIME_LOYALTY_POINTS",
 	}
 	EcommerceOfferRule_Condition_InputField_value = map[string]int32{
 		"AGE":                         0,
@@ -332,6 +642,7 @@ var (
 		"RETURN_RATE_PERCENT":         14,
 		"HAS_COUPON_REDEEMED_BEFORE":  15,
 		"SIGNUP_DAYS_AGO":             16,
+		"LIFETIME_LOYALTY_POINTS":     17,
 	}
 )
 
@@ -346,11 +657,11 @@ func (x EcommerceOfferRule_Condition_InputField) String() string {
 }
 
 func (EcommerceOfferRule_Condition_InputField) Descriptor() protoreflect.EnumDescriptor {
-	return file_ecommerce_offer_rules_proto_enumTypes[4].Descriptor()
+	return file_ecommerce_offer_rules_proto_enumTypes[8].Descriptor()
 }
 
 func (EcommerceOfferRule_Condition_InputField) Type() protoreflect.EnumType {
-	return &file_ecommerce_offer_rules_proto_enumTypes[4]
+	return &file_ecommerce_offer_rules_proto_enumTypes[8]
 }
 
 func (x EcommerceOfferRule_Condition_InputField) Number() protoreflect.EnumNumber {
@@ -359,7 +670,7 @@ func (x EcommerceOfferRule_Condition_InputField) Number() protoreflect.EnumNumbe
 
 // Deprecated: Use EcommerceOfferRule_Condition_InputField.Descriptor instead.
 func (EcommerceOfferRule_Condition_InputField) EnumDescriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1, 0, 0}
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 0, 0}
 }
 
 type EcommerceOfferRule_Action_OutputField int32
@@ -372,6 +683,7 @@ const (
 	EcommerceOfferRule_Action_ASSIGN_COUPON_CODE     EcommerceOfferRule_Action_OutputField = 4
 	EcommerceOfferRule_Action_PROMO_MESSAGE          EcommerceOfferRule_Action_OutputField = 5
 	EcommerceOfferRule_Action_ADD_LOYALTY_POINTS     EcommerceOfferRule_Action_OutputField = 6
+	EcommerceOfferRule_Action_PROMO_TAGS             EcommerceOfferRule_Action_OutputField = 7
 )
 
 // Enum value maps for EcommerceOfferRule_Action_OutputField.
@@ -384,6 +696,7 @@ var (
 		4: "ASSIGN_COUPON_CODE",
 		5: "PROMO_MESSAGE",
 		6: "ADD_LOYALTY_POINTS",
+		7: "PROMO_TAGS",
 	}
 	EcommerceOfferRule_Action_OutputField_value = map[string]int32{
 		"APPLY_DISCOUNT_PERCENT": 0,
@@ -393,65 +706,1010 @@ var (
 		"ASSIGN_COUPON_CODE":     4,
 		"PROMO_MESSAGE":          5,
 		"ADD_LOYALTY_POINTS":     6,
+		"PROMO_TAGS":             7,
+	}
+)
+
+func (x EcommerceOfferRule_Action_OutputField) Enum() *EcommerceOfferRule_Action_OutputField {
+	p := new(EcommerceOfferRule_Action_OutputField)
+	*p = x
+	return p
+}
+
+func (x EcommerceOfferRule_Action_OutputField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EcommerceOfferRule_Action_OutputField) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[9].Descriptor()
+}
+
+func (EcommerceOfferRule_Action_OutputField) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[9]
+}
+
+func (x EcommerceOfferRule_Action_OutputField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EcommerceOfferRule_Action_OutputField.Descriptor instead.
+func (EcommerceOfferRule_Action_OutputField) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 1, 0}
+}
+
+// Operators combining an action value with the current value of its output.
+// Assignment operators are written between the output and the value, the
+// others assign the result of their grl_operator template. The
+// grl_operand_types annotation lists the output field types they apply to.
+type EcommerceOfferRule_Action_Operator int32
+
+const (
+	EcommerceOfferRule_Action_SET      EcommerceOfferRule_Action_Operator = 0
+	EcommerceOfferRule_Action_ADD      EcommerceOfferRule_Action_Operator = 1
+	EcommerceOfferRule_Action_SUBTRACT EcommerceOfferRule_Action_Operator = 2
+	EcommerceOfferRule_Action_MAX      EcommerceOfferRule_Action_Operator = 3
+	EcommerceOfferRule_Action_MIN      EcommerceOfferRule_Action_Operator = 4
+	// Adds the elements of a string list value to a list output.
+	EcommerceOfferRule_Action_APPEND EcommerceOfferRule_Action_Operator = 5
+)
+
+// Enum value maps for EcommerceOfferRule_Action_Operator.
+var (
+	EcommerceOfferRule_Action_Operator_name = map[int32]string{
+		0: "SET",
+		1: "ADD",
+		2: "SUBTRACT",
+		3: "MAX",
+		4: "MIN",
+		5: "APPEND",
+	}
+	EcommerceOfferRule_Action_Operator_value = map[string]int32{
+		"SET":      0,
+		"ADD":      1,
+		"SUBTRACT": 2,
+		"MAX":      3,
+		"MIN":      4,
+		"APPEND":   5,
 	}
 )
 
-func (x EcommerceOfferRule_Action_OutputField) Enum() *EcommerceOfferRule_Action_OutputField {
-	p := new(EcommerceOfferRule_Action_OutputField)
-	*p = x
-	return p
+func (x EcommerceOfferRule_Action_Operator) Enum() *EcommerceOfferRule_Action_Operator {
+	p := new(EcommerceOfferRule_Action_Operator)
+	*p = x
+	return p
+}
+
+func (x EcommerceOfferRule_Action_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EcommerceOfferRule_Action_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_ecommerce_offer_rules_proto_enumTypes[10].Descriptor()
+}
+
+func (EcommerceOfferRule_Action_Operator) Type() protoreflect.EnumType {
+	return &file_ecommerce_offer_rules_proto_enumTypes[10]
+}
+
+func (x EcommerceOfferRule_Action_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EcommerceOfferRule_Action_Operator.Descriptor instead.
+func (EcommerceOfferRule_Action_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 1, 1}
+}
+
+type RuleValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*RuleValue_StringVal
+	//	*RuleValue_BoolVal
+	//	*RuleValue_IntVal
+	//	*RuleValue_FloatVal
+	//	*RuleValue_StringListCommaConcatenated
+	//	*RuleValue_DecimalVal
+	//	*RuleValue_RangeVal
+	//	*RuleValue_FieldRef
+	//	*RuleValue_ArithmeticVal
+	//	*RuleValue_StringList
+	//	*RuleValue_IntList
+	//	*RuleValue_FloatList
+	//	*RuleValue_LongVal
+	//	*RuleValue_DoubleVal
+	//	*RuleValue_LongList
+	//	*RuleValue_DoubleList
+	Value         isRuleValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleValue) Reset() {
+	*x = RuleValue{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleValue) ProtoMessage() {}
+
+func (x *RuleValue) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleValue.ProtoReflect.Descriptor instead.
+func (*RuleValue) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RuleValue) GetValue() isRuleValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *RuleValue) GetStringVal() string {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_StringVal); ok {
+			return x.StringVal
+		}
+	}
+	return ""
+}
+
+func (x *RuleValue) GetBoolVal() bool {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_BoolVal); ok {
+			return x.BoolVal
+		}
+	}
+	return false
+}
+
+func (x *RuleValue) GetIntVal() int32 {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_IntVal); ok {
+			return x.IntVal
+		}
+	}
+	return 0
+}
+
+func (x *RuleValue) GetFloatVal() float32 {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_FloatVal); ok {
+			return x.FloatVal
+		}
+	}
+	return 0
+}
+
+func (x *RuleValue) GetStringListCommaConcatenated() string {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_StringListCommaConcatenated); ok {
+			return x.StringListCommaConcatenated
+		}
+	}
+	return ""
+}
+
+func (x *RuleValue) GetDecimalVal() string {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_DecimalVal); ok {
+			return x.DecimalVal
+		}
+	}
+	return ""
+}
+
+func (x *RuleValue) GetRangeVal() *Range {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_RangeVal); ok {
+			return x.RangeVal
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetFieldRef() EcommerceOfferRule_Condition_InputField {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_FieldRef); ok {
+			return x.FieldRef
+		}
+	}
+	return EcommerceOfferRule_Condition_AGE
+}
+
+func (x *RuleValue) GetArithmeticVal() *Arithmetic {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_ArithmeticVal); ok {
+			return x.ArithmeticVal
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetStringList() *StringList {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_StringList); ok {
+			return x.StringList
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetIntList() *IntList {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_IntList); ok {
+			return x.IntList
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetFloatList() *FloatList {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_FloatList); ok {
+			return x.FloatList
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetLongVal() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_LongVal); ok {
+			return x.LongVal
+		}
+	}
+	return 0
+}
+
+func (x *RuleValue) GetDoubleVal() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_DoubleVal); ok {
+			return x.DoubleVal
+		}
+	}
+	return 0
+}
+
+func (x *RuleValue) GetLongList() *LongList {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_LongList); ok {
+			return x.LongList
+		}
+	}
+	return nil
+}
+
+func (x *RuleValue) GetDoubleList() *DoubleList {
+	if x != nil {
+		if x, ok := x.Value.(*RuleValue_DoubleList); ok {
+			return x.DoubleList
+		}
+	}
+	return nil
+}
+
+type isRuleValue_Value interface {
+	isRuleValue_Value()
+}
+
+type RuleValue_StringVal struct {
+	StringVal string `protobuf:"bytes,1,opt,name=string_val,json=stringVal,proto3,oneof"`
+}
+
+type RuleValue_BoolVal struct {
+	BoolVal bool `protobuf:"varint,2,opt,name=bool_val,json=boolVal,proto3,oneof"`
+}
+
+type RuleValue_IntVal struct {
+	IntVal int32 `protobuf:"varint,3,opt,name=int_val,json=intVal,proto3,oneof"`
+}
+
+type RuleValue_FloatVal struct {
+	FloatVal float32 `protobuf:"fixed32,4,opt,name=float_val,json=floatVal,proto3,oneof"`
+}
+
+type RuleValue_StringListCommaConcatenated struct {
+	// Deprecated: use string_list, this form cannot hold elements containing
+	// a comma. It is still accepted, MigrateListValues rewrites it.
+	StringListCommaConcatenated string `protobuf:"bytes,5,opt,name=string_list_comma_concatenated,json=stringListCommaConcatenated,proto3,oneof"`
+}
+
+type RuleValue_DecimalVal struct {
+	// Decimal number kept as text, e.g. "1299.99", for amounts that must not
+	// be rounded through float.
+	DecimalVal string `protobuf:"bytes,6,opt,name=decimal_val,json=decimalVal,proto3,oneof"`
+}
+
+type RuleValue_RangeVal struct {
+	RangeVal *Range `protobuf:"bytes,7,opt,name=range_val,json=rangeVal,proto3,oneof"`
+}
+
+type RuleValue_FieldRef struct {
+	// Another input field, written as its grl_field_name. Its grl_field_type
+	// must be accepted by the field it is compared with or assigned to.
+	FieldRef EcommerceOfferRule_Condition_InputField `protobuf:"varint,8,opt,name=field_ref,json=fieldRef,proto3,enum=ecommerce.v1.rules.EcommerceOfferRule_Condition_InputField,oneof"`
+}
+
+type RuleValue_ArithmeticVal struct {
+	// Value computed when the rule runs.
+	ArithmeticVal *Arithmetic `protobuf:"bytes,9,opt,name=arithmetic_val,json=arithmeticVal,proto3,oneof"`
+}
+
+type RuleValue_StringList struct {
+	// Lists, used by list fields and as the candidates of IN and NOT_IN.
+	StringList *StringList `protobuf:"bytes,10,opt,name=string_list,json=stringList,proto3,oneof"`
+}
+
+type RuleValue_IntList struct {
+	IntList *IntList `protobuf:"bytes,11,opt,name=int_list,json=intList,proto3,oneof"`
+}
+
+type RuleValue_FloatList struct {
+	FloatList *FloatList `protobuf:"bytes,12,opt,name=float_list,json=floatList,proto3,oneof"`
+}
+
+type RuleValue_LongVal struct {
+	// Values of LONG and DOUBLE fields, beyond the range or precision of
+	// int_val and float_val.
+	LongVal int64 `protobuf:"varint,13,opt,name=long_val,json=longVal,proto3,oneof"`
+}
+
+type RuleValue_DoubleVal struct {
+	DoubleVal float64 `protobuf:"fixed64,14,opt,name=double_val,json=doubleVal,proto3,oneof"`
+}
+
+type RuleValue_LongList struct {
+	// Candidates of LONG and DOUBLE fields.
+	LongList *LongList `protobuf:"bytes,15,opt,name=long_list,json=longList,proto3,oneof"`
+}
+
+type RuleValue_DoubleList struct {
+	DoubleList *DoubleList `protobuf:"bytes,16,opt,name=double_list,json=doubleList,proto3,oneof"`
+}
+
+func (*RuleValue_StringVal) isRuleValue_Value() {}
+
+func (*RuleValue_BoolVal) isRuleValue_Value() {}
+
+func (*RuleValue_IntVal) isRuleValue_Value() {}
+
+func (*RuleValue_FloatVal) isRuleValue_Value() {}
+
+func (*RuleValue_StringListCommaConcatenated) isRuleValue_Value() {}
+
+func (*RuleValue_DecimalVal) isRuleValue_Value() {}
+
+func (*RuleValue_RangeVal) isRuleValue_Value() {}
+
+func (*RuleValue_FieldRef) isRuleValue_Value() {}
+
+func (*RuleValue_ArithmeticVal) isRuleValue_Value() {}
+
+func (*RuleValue_StringList) isRuleValue_Value() {}
+
+func (*RuleValue_IntList) isRuleValue_Value() {}
+
+func (*RuleValue_FloatList) isRuleValue_Value() {}
+
+func (*RuleValue_LongVal) isRuleValue_Value() {}
+
+func (*RuleValue_DoubleVal) isRuleValue_Value() {}
+
+func (*RuleValue_LongList) isRuleValue_Value() {}
+
+func (*RuleValue_DoubleList) isRuleValue_Value() {}
+
+type StringList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type IntList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int32                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntList) Reset() {
+	*x = IntList{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntList) ProtoMessage() {}
+
+func (x *IntList) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntList.ProtoReflect.Descriptor instead.
+func (*IntList) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IntList) GetValues() []int32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type FloatList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FloatList) Reset() {
+	*x = FloatList{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FloatList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FloatList) ProtoMessage() {}
+
+func (x *FloatList) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FloatList.ProtoReflect.Descriptor instead.
+func (*FloatList) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FloatList) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type LongList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int64                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LongList) Reset() {
+	*x = LongList{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LongList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LongList) ProtoMessage() {}
+
+func (x *LongList) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LongList.ProtoReflect.Descriptor instead.
+func (*LongList) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LongList) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DoubleList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoubleList) Reset() {
+	*x = DoubleList{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoubleList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleList) ProtoMessage() {}
+
+func (x *DoubleList) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleList.ProtoReflect.Descriptor instead.
+func (*DoubleList) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DoubleList) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Computed value: the operator applied to at least two operands, each a
+// numeric literal, a reference to a numeric field or another Arithmetic.
+type Arithmetic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      ArithmeticOperator     `protobuf:"varint,1,opt,name=operator,proto3,enum=ecommerce.v1.rules.ArithmeticOperator" json:"operator,omitempty"`
+	Operands      []*RuleValue           `protobuf:"bytes,2,rep,name=operands,proto3" json:"operands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Arithmetic) Reset() {
+	*x = Arithmetic{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Arithmetic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Arithmetic) ProtoMessage() {}
+
+func (x *Arithmetic) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Arithmetic.ProtoReflect.Descriptor instead.
+func (*Arithmetic) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Arithmetic) GetOperator() ArithmeticOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return ArithmeticOperator_ARITHMETIC_OPERATOR_UNSPECIFIED
+}
+
+func (x *Arithmetic) GetOperands() []*RuleValue {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+// Numeric range used by BETWEEN and NOT_BETWEEN. Bounds are inclusive unless
+// marked exclusive.
+type Range struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Lower          *RuleValue             `protobuf:"bytes,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper          *RuleValue             `protobuf:"bytes,2,opt,name=upper,proto3" json:"upper,omitempty"`
+	LowerExclusive bool                   `protobuf:"varint,3,opt,name=lower_exclusive,json=lowerExclusive,proto3" json:"lower_exclusive,omitempty"`
+	UpperExclusive bool                   `protobuf:"varint,4,opt,name=upper_exclusive,json=upperExclusive,proto3" json:"upper_exclusive,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Range) GetLower() *RuleValue {
+	if x != nil {
+		return x.Lower
+	}
+	return nil
+}
+
+func (x *Range) GetUpper() *RuleValue {
+	if x != nil {
+		return x.Upper
+	}
+	return nil
+}
+
+func (x *Range) GetLowerExclusive() bool {
+	if x != nil {
+		return x.LowerExclusive
+	}
+	return false
+}
+
+func (x *Range) GetUpperExclusive() bool {
+	if x != nil {
+		return x.UpperExclusive
+	}
+	return false
+}
+
+// Ecommerce offer rule.
+type EcommerceOfferRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the rule.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Description of the rule.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Salience of the rule used in GRule.
+	Salience uint32 `protobuf:"varint,3,opt,name=salience,proto3" json:"salience,omitempty"`
+	// Represents the operator to join multiple conditions.
+	Conditions []*EcommerceOfferRule_Condition `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// Represents the operator to join multiple conditions.
+	ConditionJoinOperator GRuleJoinOperator `protobuf:"varint,5,opt,name=condition_join_operator,json=conditionJoinOperator,proto3,enum=ecommerce.v1.rules.GRuleJoinOperator" json:"condition_join_operator,omitempty"`
+	// Represents the actions to be performed.
+	Actions []*EcommerceOfferRule_Action `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`
+	// Represents how the rule ends once its actions ran.
+	TerminationMode GRuleTerminationMode `protobuf:"varint,7,opt,name=termination_mode,json=terminationMode,proto3,enum=ecommerce.v1.rules.GRuleTerminationMode" json:"termination_mode,omitempty"`
+	// Represents the conditions as a boolean tree of any depth. It is used
+	// instead of conditions and condition_join_operator, which cannot both be set.
+	ConditionTree *ConditionNode `protobuf:"bytes,8,opt,name=condition_tree,json=conditionTree,proto3" json:"condition_tree,omitempty"`
+	// Time window the rule applies in, checked when the rule runs through the
+	// Clock fact. valid_from is inclusive and valid_until exclusive, an unset
+	// bound leaves the window open on that side.
+	ValidFrom  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidUntil *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+	// Bookkeeping about the rule, carried through GRL as comments.
+	Metadata      *RuleMetadata `protobuf:"bytes,11,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EcommerceOfferRule) Reset() {
+	*x = EcommerceOfferRule{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EcommerceOfferRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EcommerceOfferRule) ProtoMessage() {}
+
+func (x *EcommerceOfferRule) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EcommerceOfferRule.ProtoReflect.Descriptor instead.
+func (*EcommerceOfferRule) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EcommerceOfferRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EcommerceOfferRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EcommerceOfferRule) GetSalience() uint32 {
+	if x != nil {
+		return x.Salience
+	}
+	return 0
+}
+
+func (x *EcommerceOfferRule) GetConditions() []*EcommerceOfferRule_Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *EcommerceOfferRule) GetConditionJoinOperator() GRuleJoinOperator {
+	if x != nil {
+		return x.ConditionJoinOperator
+	}
+	return GRuleJoinOperator_JOIN_OPERATOR_UNSPECIFIED
+}
+
+func (x *EcommerceOfferRule) GetActions() []*EcommerceOfferRule_Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *EcommerceOfferRule) GetTerminationMode() GRuleTerminationMode {
+	if x != nil {
+		return x.TerminationMode
+	}
+	return GRuleTerminationMode_RETRACT_SELF
+}
+
+func (x *EcommerceOfferRule) GetConditionTree() *ConditionNode {
+	if x != nil {
+		return x.ConditionTree
+	}
+	return nil
+}
+
+func (x *EcommerceOfferRule) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *EcommerceOfferRule) GetValidUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return nil
+}
+
+func (x *EcommerceOfferRule) GetMetadata() *RuleMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Who owns a rule and why it exists. It does not change what the rule does and
+// is written as `// @key: value` comment lines above the GRL rule, one line per
+// label and per line of notes.
+type RuleMetadata struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OwnerTeam string                 `protobuf:"bytes,1,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	// Ticket the rule was requested in, e.g. a JIRA issue key.
+	Ticket    string   `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	CreatedBy string   `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Labels    []string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	// Free-form text, it may span several lines.
+	Notes         string `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleMetadata) Reset() {
+	*x = RuleMetadata{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleMetadata) ProtoMessage() {}
+
+func (x *RuleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleMetadata.ProtoReflect.Descriptor instead.
+func (*RuleMetadata) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{9}
 }
 
-func (x EcommerceOfferRule_Action_OutputField) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x *RuleMetadata) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
 }
 
-func (EcommerceOfferRule_Action_OutputField) Descriptor() protoreflect.EnumDescriptor {
-	return file_ecommerce_offer_rules_proto_enumTypes[5].Descriptor()
+func (x *RuleMetadata) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
 }
 
-func (EcommerceOfferRule_Action_OutputField) Type() protoreflect.EnumType {
-	return &file_ecommerce_offer_rules_proto_enumTypes[5]
+func (x *RuleMetadata) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
 }
 
-func (x EcommerceOfferRule_Action_OutputField) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *RuleMetadata) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
 }
 
-// Deprecated: Use EcommerceOfferRule_Action_OutputField.Descriptor instead.
-func (EcommerceOfferRule_Action_OutputField) EnumDescriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1, 1, 0}
+func (x *RuleMetadata) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
 }
 
-type RuleValue struct {
+// Node of a boolean condition tree: a single expression, an AND/OR group of
+// child nodes, the negation of a node or a recurring schedule.
+type ConditionNode struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Value:
+	// Types that are valid to be assigned to Node:
 	//
-	//	*RuleValue_StringVal
-	//	*RuleValue_BoolVal
-	//	*RuleValue_IntVal
-	//	*RuleValue_FloatVal
-	//	*RuleValue_StringListCommaConcatenated
-	Value         isRuleValue_Value `protobuf_oneof:"value"`
+	//	*ConditionNode_Expression
+	//	*ConditionNode_Group_
+	//	*ConditionNode_Not
+	//	*ConditionNode_Schedule
+	Node          isConditionNode_Node `protobuf_oneof:"node"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RuleValue) Reset() {
-	*x = RuleValue{}
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[0]
+func (x *ConditionNode) Reset() {
+	*x = ConditionNode{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RuleValue) String() string {
+func (x *ConditionNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RuleValue) ProtoMessage() {}
+func (*ConditionNode) ProtoMessage() {}
 
-func (x *RuleValue) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[0]
+func (x *ConditionNode) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,131 +1720,110 @@ func (x *RuleValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RuleValue.ProtoReflect.Descriptor instead.
-func (*RuleValue) Descriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ConditionNode.ProtoReflect.Descriptor instead.
+func (*ConditionNode) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RuleValue) GetValue() isRuleValue_Value {
+func (x *ConditionNode) GetNode() isConditionNode_Node {
 	if x != nil {
-		return x.Value
+		return x.Node
 	}
 	return nil
 }
 
-func (x *RuleValue) GetStringVal() string {
-	if x != nil {
-		if x, ok := x.Value.(*RuleValue_StringVal); ok {
-			return x.StringVal
-		}
-	}
-	return ""
-}
-
-func (x *RuleValue) GetBoolVal() bool {
+func (x *ConditionNode) GetExpression() *EcommerceOfferRule_Condition_Expression {
 	if x != nil {
-		if x, ok := x.Value.(*RuleValue_BoolVal); ok {
-			return x.BoolVal
+		if x, ok := x.Node.(*ConditionNode_Expression); ok {
+			return x.Expression
 		}
 	}
-	return false
+	return nil
 }
 
-func (x *RuleValue) GetIntVal() int32 {
+func (x *ConditionNode) GetGroup() *ConditionNode_Group {
 	if x != nil {
-		if x, ok := x.Value.(*RuleValue_IntVal); ok {
-			return x.IntVal
+		if x, ok := x.Node.(*ConditionNode_Group_); ok {
+			return x.Group
 		}
 	}
-	return 0
+	return nil
 }
 
-func (x *RuleValue) GetFloatVal() float32 {
+func (x *ConditionNode) GetNot() *ConditionNode {
 	if x != nil {
-		if x, ok := x.Value.(*RuleValue_FloatVal); ok {
-			return x.FloatVal
+		if x, ok := x.Node.(*ConditionNode_Not); ok {
+			return x.Not
 		}
 	}
-	return 0
+	return nil
 }
 
-func (x *RuleValue) GetStringListCommaConcatenated() string {
+func (x *ConditionNode) GetSchedule() *Schedule {
 	if x != nil {
-		if x, ok := x.Value.(*RuleValue_StringListCommaConcatenated); ok {
-			return x.StringListCommaConcatenated
+		if x, ok := x.Node.(*ConditionNode_Schedule); ok {
+			return x.Schedule
 		}
 	}
-	return ""
-}
-
-type isRuleValue_Value interface {
-	isRuleValue_Value()
+	return nil
 }
 
-type RuleValue_StringVal struct {
-	StringVal string `protobuf:"bytes,1,opt,name=string_val,json=stringVal,proto3,oneof"`
+type isConditionNode_Node interface {
+	isConditionNode_Node()
 }
 
-type RuleValue_BoolVal struct {
-	BoolVal bool `protobuf:"varint,2,opt,name=bool_val,json=boolVal,proto3,oneof"`
+type ConditionNode_Expression struct {
+	Expression *EcommerceOfferRule_Condition_Expression `protobuf:"bytes,1,opt,name=expression,proto3,oneof"`
 }
 
-type RuleValue_IntVal struct {
-	IntVal int32 `protobuf:"varint,3,opt,name=int_val,json=intVal,proto3,oneof"`
+type ConditionNode_Group_ struct {
+	Group *ConditionNode_Group `protobuf:"bytes,2,opt,name=group,proto3,oneof"`
 }
 
-type RuleValue_FloatVal struct {
-	FloatVal float32 `protobuf:"fixed32,4,opt,name=float_val,json=floatVal,proto3,oneof"`
+type ConditionNode_Not struct {
+	Not *ConditionNode `protobuf:"bytes,3,opt,name=not,proto3,oneof"`
 }
 
-type RuleValue_StringListCommaConcatenated struct {
-	StringListCommaConcatenated string `protobuf:"bytes,5,opt,name=string_list_comma_concatenated,json=stringListCommaConcatenated,proto3,oneof"`
+type ConditionNode_Schedule struct {
+	Schedule *Schedule `protobuf:"bytes,4,opt,name=schedule,proto3,oneof"`
 }
 
-func (*RuleValue_StringVal) isRuleValue_Value() {}
-
-func (*RuleValue_BoolVal) isRuleValue_Value() {}
+func (*ConditionNode_Expression) isConditionNode_Node() {}
 
-func (*RuleValue_IntVal) isRuleValue_Value() {}
+func (*ConditionNode_Group_) isConditionNode_Node() {}
 
-func (*RuleValue_FloatVal) isRuleValue_Value() {}
+func (*ConditionNode_Not) isConditionNode_Node() {}
 
-func (*RuleValue_StringListCommaConcatenated) isRuleValue_Value() {}
+func (*ConditionNode_Schedule) isConditionNode_Node() {}
 
-// Ecommerce offer rule.
-type EcommerceOfferRule struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Name of the rule.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Description of the rule.
-	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	// Salience of the rule used in GRule.
-	Salience uint32 `protobuf:"varint,3,opt,name=salience,proto3" json:"salience,omitempty"`
-	// Represents the operator to join multiple conditions.
-	Conditions []*EcommerceOfferRule_Condition `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
-	// Represents the operator to join multiple conditions.
-	ConditionJoinOperator GRuleJoinOperator `protobuf:"varint,5,opt,name=condition_join_operator,json=conditionJoinOperator,proto3,enum=ecommerce.v1.rules.GRuleJoinOperator" json:"condition_join_operator,omitempty"`
-	// Represents the actions to be performed.
-	Actions       []*EcommerceOfferRule_Action `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`
+// Recurring weekly window in a time zone, checked when the rule runs through
+// the Clock fact. A schedule without days applies every day, one without time
+// ranges all day long.
+type Schedule struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Days       []Weekday              `protobuf:"varint,1,rep,packed,name=days,proto3,enum=ecommerce.v1.rules.Weekday" json:"days,omitempty"`
+	TimeRanges []*Schedule_TimeRange  `protobuf:"bytes,2,rep,name=time_ranges,json=timeRanges,proto3" json:"time_ranges,omitempty"`
+	// IANA time zone name such as "Europe/Berlin", UTC when empty.
+	TimeZone      string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EcommerceOfferRule) Reset() {
-	*x = EcommerceOfferRule{}
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[1]
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EcommerceOfferRule) String() string {
+func (x *Schedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EcommerceOfferRule) ProtoMessage() {}
+func (*Schedule) ProtoMessage() {}
 
-func (x *EcommerceOfferRule) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[1]
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -597,51 +1834,30 @@ func (x *EcommerceOfferRule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EcommerceOfferRule.ProtoReflect.Descriptor instead.
-func (*EcommerceOfferRule) Descriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *EcommerceOfferRule) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *EcommerceOfferRule) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *EcommerceOfferRule) GetSalience() uint32 {
-	if x != nil {
-		return x.Salience
-	}
-	return 0
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *EcommerceOfferRule) GetConditions() []*EcommerceOfferRule_Condition {
+func (x *Schedule) GetDays() []Weekday {
 	if x != nil {
-		return x.Conditions
+		return x.Days
 	}
 	return nil
 }
 
-func (x *EcommerceOfferRule) GetConditionJoinOperator() GRuleJoinOperator {
+func (x *Schedule) GetTimeRanges() []*Schedule_TimeRange {
 	if x != nil {
-		return x.ConditionJoinOperator
+		return x.TimeRanges
 	}
-	return GRuleJoinOperator_JOIN_OPERATOR_UNSPECIFIED
+	return nil
 }
 
-func (x *EcommerceOfferRule) GetActions() []*EcommerceOfferRule_Action {
+func (x *Schedule) GetTimeZone() string {
 	if x != nil {
-		return x.Actions
+		return x.TimeZone
 	}
-	return nil
+	return ""
 }
 
 // Conditions to be tested for the rule.
@@ -657,7 +1873,7 @@ type EcommerceOfferRule_Condition struct {
 
 func (x *EcommerceOfferRule_Condition) Reset() {
 	*x = EcommerceOfferRule_Condition{}
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[2]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -669,7 +1885,7 @@ func (x *EcommerceOfferRule_Condition) String() string {
 func (*EcommerceOfferRule_Condition) ProtoMessage() {}
 
 func (x *EcommerceOfferRule_Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[2]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -682,7 +1898,7 @@ func (x *EcommerceOfferRule_Condition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EcommerceOfferRule_Condition.ProtoReflect.Descriptor instead.
 func (*EcommerceOfferRule_Condition) Descriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1, 0}
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *EcommerceOfferRule_Condition) GetExpressions() []*EcommerceOfferRule_Condition_Expression {
@@ -705,14 +1921,16 @@ type EcommerceOfferRule_Action struct {
 	// Represents the action to be performed.
 	Output EcommerceOfferRule_Action_OutputField `protobuf:"varint,1,opt,name=output,proto3,enum=ecommerce.v1.rules.EcommerceOfferRule_Action_OutputField" json:"output,omitempty"`
 	// Represents the value to be set for the action.
-	Value         *RuleValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Value *RuleValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Represents how the value is combined with the current value of the output.
+	Operator      EcommerceOfferRule_Action_Operator `protobuf:"varint,3,opt,name=operator,proto3,enum=ecommerce.v1.rules.EcommerceOfferRule_Action_Operator" json:"operator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EcommerceOfferRule_Action) Reset() {
 	*x = EcommerceOfferRule_Action{}
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[3]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -724,7 +1942,7 @@ func (x *EcommerceOfferRule_Action) String() string {
 func (*EcommerceOfferRule_Action) ProtoMessage() {}
 
 func (x *EcommerceOfferRule_Action) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[3]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -737,7 +1955,7 @@ func (x *EcommerceOfferRule_Action) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EcommerceOfferRule_Action.ProtoReflect.Descriptor instead.
 func (*EcommerceOfferRule_Action) Descriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1, 1}
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *EcommerceOfferRule_Action) GetOutput() EcommerceOfferRule_Action_OutputField {
@@ -754,19 +1972,28 @@ func (x *EcommerceOfferRule_Action) GetValue() *RuleValue {
 	return nil
 }
 
+func (x *EcommerceOfferRule_Action) GetOperator() EcommerceOfferRule_Action_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return EcommerceOfferRule_Action_SET
+}
+
 // Represents the operator to be used in the expression.
 type EcommerceOfferRule_Condition_Expression struct {
-	state         protoimpl.MessageState                  `protogen:"open.v1"`
-	Input         EcommerceOfferRule_Condition_InputField `protobuf:"varint,1,opt,name=input,proto3,enum=ecommerce.v1.rules.EcommerceOfferRule_Condition_InputField" json:"input,omitempty"`
-	Operator      GRuleExpressionOperator                 `protobuf:"varint,2,opt,name=operator,proto3,enum=ecommerce.v1.rules.GRuleExpressionOperator" json:"operator,omitempty"`
-	Value         *RuleValue                              `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	state    protoimpl.MessageState                  `protogen:"open.v1"`
+	Input    EcommerceOfferRule_Condition_InputField `protobuf:"varint,1,opt,name=input,proto3,enum=ecommerce.v1.rules.EcommerceOfferRule_Condition_InputField" json:"input,omitempty"`
+	Operator GRuleExpressionOperator                 `protobuf:"varint,2,opt,name=operator,proto3,enum=ecommerce.v1.rules.GRuleExpressionOperator" json:"operator,omitempty"`
+	Value    *RuleValue                              `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Compares an aggregate of the input field instead of the field itself.
+	Aggregate     GRuleAggregate `protobuf:"varint,4,opt,name=aggregate,proto3,enum=ecommerce.v1.rules.GRuleAggregate" json:"aggregate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EcommerceOfferRule_Condition_Expression) Reset() {
 	*x = EcommerceOfferRule_Condition_Expression{}
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[4]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -778,7 +2005,7 @@ func (x *EcommerceOfferRule_Condition_Expression) String() string {
 func (*EcommerceOfferRule_Condition_Expression) ProtoMessage() {}
 
 func (x *EcommerceOfferRule_Condition_Expression) ProtoReflect() protoreflect.Message {
-	mi := &file_ecommerce_offer_rules_proto_msgTypes[4]
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,7 +2018,7 @@ func (x *EcommerceOfferRule_Condition_Expression) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use EcommerceOfferRule_Condition_Expression.ProtoReflect.Descriptor instead.
 func (*EcommerceOfferRule_Condition_Expression) Descriptor() ([]byte, []int) {
-	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{1, 0, 0}
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{8, 0, 0}
 }
 
 func (x *EcommerceOfferRule_Condition_Expression) GetInput() EcommerceOfferRule_Condition_InputField {
@@ -815,6 +2042,120 @@ func (x *EcommerceOfferRule_Condition_Expression) GetValue() *RuleValue {
 	return nil
 }
 
+func (x *EcommerceOfferRule_Condition_Expression) GetAggregate() GRuleAggregate {
+	if x != nil {
+		return x.Aggregate
+	}
+	return GRuleAggregate_AGGREGATE_UNSPECIFIED
+}
+
+// Represents child nodes joined by one operator.
+type ConditionNode_Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      GRuleJoinOperator      `protobuf:"varint,1,opt,name=operator,proto3,enum=ecommerce.v1.rules.GRuleJoinOperator" json:"operator,omitempty"`
+	Children      []*ConditionNode       `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionNode_Group) Reset() {
+	*x = ConditionNode_Group{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionNode_Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionNode_Group) ProtoMessage() {}
+
+func (x *ConditionNode_Group) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionNode_Group.ProtoReflect.Descriptor instead.
+func (*ConditionNode_Group) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *ConditionNode_Group) GetOperator() GRuleJoinOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return GRuleJoinOperator_JOIN_OPERATOR_UNSPECIFIED
+}
+
+func (x *ConditionNode_Group) GetChildren() []*ConditionNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Local times of day "HH:MM", from start inclusive to end exclusive. end may
+// be "24:00" and must be after start.
+type Schedule_TimeRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule_TimeRange) Reset() {
+	*x = Schedule_TimeRange{}
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule_TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule_TimeRange) ProtoMessage() {}
+
+func (x *Schedule_TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_ecommerce_offer_rules_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule_TimeRange.ProtoReflect.Descriptor instead.
+func (*Schedule_TimeRange) Descriptor() ([]byte, []int) {
+	return file_ecommerce_offer_rules_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *Schedule_TimeRange) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *Schedule_TimeRange) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
 var file_ecommerce_offer_rules_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
@@ -840,6 +2181,22 @@ var file_ecommerce_offer_rules_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,1003,opt,name=grl_operator",
 		Filename:      "ecommerce_offer_rules.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: ([]FieldType)(nil),
+		Field:         1004,
+		Name:          "ecommerce.v1.rules.grl_operand_types",
+		Tag:           "varint,1004,rep,packed,name=grl_operand_types,enum=ecommerce.v1.rules.FieldType",
+		Filename:      "ecommerce_offer_rules.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         1005,
+		Name:          "ecommerce.v1.rules.grl_precision",
+		Tag:           "varint,1005,opt,name=grl_precision",
+		Filename:      "ecommerce_offer_rules.proto",
+	},
 }
 
 // Extension fields to descriptorpb.EnumValueOptions.
@@ -850,6 +2207,15 @@ var (
 	E_GrlFieldType = &file_ecommerce_offer_rules_proto_extTypes[1]
 	// optional string grl_operator = 1003;
 	E_GrlOperator = &file_ecommerce_offer_rules_proto_extTypes[2]
+	// Field types an expression operator can be applied to.
+	//
+	// repeated ecommerce.v1.rules.FieldType grl_operand_types = 1004;
+	E_GrlOperandTypes = &file_ecommerce_offer_rules_proto_extTypes[3]
+	// Number of decimal places float values of the field are written with.
+	// Fields without it use the shortest representation that round-trips.
+	//
+	// optional int32 grl_precision = 1005;
+	E_GrlPrecision = &file_ecommerce_offer_rules_proto_extTypes[4]
 )
 
 var File_ecommerce_offer_rules_proto protoreflect.FileDescriptor
@@ -860,222 +2226,498 @@ var file_ecommerce_offer_rules_proto_rawDesc = string([]byte{
 	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65,
 	0x73, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x01, 0x0a, 0x09, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
-	0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x12,
-	0x19, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x48, 0x00, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x09, 0x66, 0x6c,
-	0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52,
-	0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x45, 0x0a, 0x1e, 0x73, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x5f, 0x63,
-	0x6f, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x1b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x43, 0x6f, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x6e, 0x61, 0x74, 0x65, 0x64,
-	0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd1, 0x11, 0x0a, 0x12, 0x45, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x61, 0x6c, 0x69, 0x65, 0x6e,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x61, 0x6c, 0x69, 0x65, 0x6e,
-	0x63, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5d, 0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65,
-	0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x15, 0x63, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x12, 0x47, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xdf, 0x0a, 0x0a,
-	0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x0b, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x3b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72,
-	0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66,
-	0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5f, 0x0a, 0x18, 0x65, 0x78, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x65, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73,
-	0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x52, 0x16, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4a, 0x6f,
-	0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0xdd, 0x01, 0x0a, 0x0a, 0x45,
-	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x05, 0x69, 0x6e, 0x70,
-	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
-	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63,
-	0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65,
-	0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x47, 0x0a, 0x08,
-	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b,
-	0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75,
-	0x6c, 0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x06, 0x0a, 0x09, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x56, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x08, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c,
+	0x12, 0x19, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x00, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x09, 0x66,
+	0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00,
+	0x52, 0x08, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x12, 0x45, 0x0a, 0x1e, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x5f,
+	0x63, 0x6f, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x1b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x43, 0x6f, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x6e, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x21, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x76, 0x61, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61,
+	0x6c, 0x56, 0x61, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x76, 0x61,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x56, 0x61, 0x6c, 0x12, 0x5a,
+	0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x3b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x48, 0x00,
+	0x52, 0x08, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x12, 0x47, 0x0a, 0x0e, 0x61, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74,
+	0x69, 0x63, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63,
+	0x56, 0x61, 0x6c, 0x12, 0x41, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x49, 0x6e,
+	0x74, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x3e, 0x0a, 0x0a, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0c,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x4c,
+	0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x08, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x6f, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x12, 0x1f, 0x0a,
+	0x0a, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x00, 0x52, 0x09, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x12, 0x3b,
+	0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x4c, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x08, 0x6c, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x0b, 0x64,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0a, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x07,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x24, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x21, 0x0a,
+	0x07, 0x49, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x22, 0x23, 0x0a, 0x09, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x22, 0x0a, 0x08, 0x4c, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x24, 0x0a, 0x0a, 0x44, 0x6f, 0x75,
+	0x62, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22,
+	0x8b, 0x01, 0x0a, 0x0a, 0x41, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63, 0x12, 0x42,
+	0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x26, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74, 0x69, 0x63,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65,
 	0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb1, 0x07, 0x0a, 0x0a, 0x49,
-	0x6e, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x03, 0x41, 0x47, 0x45,
-	0x10, 0x00, 0x1a, 0x12, 0xca, 0x3e, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e,
-	0x41, 0x67, 0x65, 0xd0, 0x3e, 0x03, 0x12, 0x21, 0x0a, 0x06, 0x47, 0x45, 0x4e, 0x44, 0x45, 0x52,
-	0x10, 0x01, 0x1a, 0x15, 0xca, 0x3e, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0xd0, 0x3e, 0x01, 0x12, 0x25, 0x0a, 0x08, 0x4c, 0x4f, 0x43,
-	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x1a, 0x17, 0xca, 0x3e, 0x11, 0x43, 0x75, 0x73, 0x74,
-	0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0xd0, 0x3e, 0x01,
-	0x12, 0x2a, 0x0a, 0x0b, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10,
-	0x03, 0x1a, 0x19, 0xca, 0x3e, 0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0xd0, 0x3e, 0x01, 0x12, 0x44, 0x0a, 0x19,
-	0x49, 0x53, 0x5f, 0x4c, 0x4f, 0x59, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52,
-	0x41, 0x4d, 0x5f, 0x4d, 0x45, 0x4d, 0x42, 0x45, 0x52, 0x10, 0x04, 0x1a, 0x25, 0xca, 0x3e, 0x1f,
-	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x49, 0x73, 0x4c, 0x6f, 0x79, 0x61, 0x6c,
-	0x74, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0xd0,
-	0x3e, 0x02, 0x12, 0x33, 0x0a, 0x14, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x5f, 0x4c, 0x49, 0x46, 0x45,
-	0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x54, 0x10, 0x05, 0x1a, 0x19, 0xca, 0x3e,
-	0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53,
-	0x70, 0x65, 0x6e, 0x74, 0xd0, 0x3e, 0x05, 0x12, 0x31, 0x0a, 0x0f, 0x41, 0x56, 0x47, 0x5f, 0x4f,
-	0x52, 0x44, 0x45, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x06, 0x1a, 0x1c, 0xca, 0x3e,
-	0x16, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x41, 0x76, 0x67, 0x4f, 0x72, 0x64,
-	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0xd0, 0x3e, 0x05, 0x12, 0x3e, 0x0a, 0x16, 0x4c, 0x41,
-	0x53, 0x54, 0x5f, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x44, 0x41, 0x59, 0x53,
-	0x5f, 0x41, 0x47, 0x4f, 0x10, 0x07, 0x1a, 0x22, 0xca, 0x3e, 0x1c, 0x43, 0x75, 0x73, 0x74, 0x6f,
-	0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65,
-	0x44, 0x61, 0x79, 0x73, 0x41, 0x67, 0x6f, 0xd0, 0x3e, 0x03, 0x12, 0x41, 0x0a, 0x17, 0x4c, 0x41,
-	0x53, 0x54, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x50, 0x55, 0x52, 0x43,
-	0x48, 0x41, 0x53, 0x45, 0x44, 0x10, 0x08, 0x1a, 0x24, 0xca, 0x3e, 0x1e, 0x43, 0x75, 0x73, 0x74,
-	0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x64, 0xd0, 0x3e, 0x01, 0x12, 0x3c, 0x0a,
-	0x14, 0x50, 0x52, 0x45, 0x46, 0x45, 0x52, 0x52, 0x45, 0x44, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47,
-	0x4f, 0x52, 0x49, 0x45, 0x53, 0x10, 0x09, 0x1a, 0x22, 0xca, 0x3e, 0x1c, 0x43, 0x75, 0x73, 0x74,
-	0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x43, 0x61,
-	0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0xd0, 0x3e, 0x07, 0x12, 0x28, 0x0a, 0x0a, 0x43,
-	0x41, 0x52, 0x54, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x10, 0x0a, 0x1a, 0x18, 0xca, 0x3e, 0x12,
-	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x72, 0x74, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0xd0, 0x3e, 0x05, 0x12, 0x43, 0x0a, 0x18, 0x43, 0x41, 0x52, 0x54, 0x5f, 0x43, 0x4f,
-	0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45,
-	0x53, 0x10, 0x0b, 0x1a, 0x25, 0xca, 0x3e, 0x1f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
-	0x2e, 0x43, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0xd0, 0x3e, 0x07, 0x12, 0x3a, 0x0a, 0x13, 0x42, 0x52,
-	0x4f, 0x57, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45,
-	0x53, 0x10, 0x0c, 0x1a, 0x21, 0xca, 0x3e, 0x1b, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
-	0x2e, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x69, 0x65, 0x73, 0xd0, 0x3e, 0x07, 0x12, 0x40, 0x0a, 0x1b, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41,
-	0x53, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x33, 0x30,
-	0x5f, 0x44, 0x41, 0x59, 0x53, 0x10, 0x0d, 0x1a, 0x1f, 0xca, 0x3e, 0x19, 0x43, 0x75, 0x73, 0x74,
-	0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x33, 0x30, 0x64, 0xd0, 0x3e, 0x03, 0x12, 0x39, 0x0a, 0x13, 0x52, 0x45, 0x54, 0x55,
-	0x52, 0x4e, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x10,
-	0x0e, 0x1a, 0x20, 0xca, 0x3e, 0x1a, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x52,
-	0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
-	0xd0, 0x3e, 0x05, 0x12, 0x46, 0x0a, 0x1a, 0x48, 0x41, 0x53, 0x5f, 0x43, 0x4f, 0x55, 0x50, 0x4f,
-	0x4e, 0x5f, 0x52, 0x45, 0x44, 0x45, 0x45, 0x4d, 0x45, 0x44, 0x5f, 0x42, 0x45, 0x46, 0x4f, 0x52,
-	0x45, 0x10, 0x0f, 0x1a, 0x26, 0xca, 0x3e, 0x20, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
-	0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x70,
-	0x6f, 0x6e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0xd0, 0x3e, 0x02, 0x12, 0x31, 0x0a, 0x0f, 0x53,
-	0x49, 0x47, 0x4e, 0x55, 0x50, 0x5f, 0x44, 0x41, 0x59, 0x53, 0x5f, 0x41, 0x47, 0x4f, 0x10, 0x10,
-	0x1a, 0x1c, 0xca, 0x3e, 0x16, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x53, 0x69,
-	0x67, 0x6e, 0x75, 0x70, 0x44, 0x61, 0x79, 0x73, 0x41, 0x67, 0x6f, 0xd0, 0x3e, 0x03, 0x1a, 0x8c,
-	0x04, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x06, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x39, 0x2e, 0x65, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45,
-	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c,
-	0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x33, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63,
+	0x6c, 0x75, 0x65, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x22, 0xc3, 0x01,
+	0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x05,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63,
 	0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73,
-	0x2e, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0xf9, 0x02, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x12, 0x3c, 0x0a, 0x16, 0x41, 0x50, 0x50, 0x4c, 0x59, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f,
-	0x55, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x1a, 0x20, 0xca,
-	0x3e, 0x1a, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0xd0, 0x3e, 0x05, 0x12,
-	0x36, 0x0a, 0x13, 0x41, 0x50, 0x50, 0x4c, 0x59, 0x5f, 0x46, 0x4c, 0x41, 0x54, 0x5f, 0x44, 0x49,
-	0x53, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x1a, 0x1d, 0xca, 0x3e, 0x17, 0x4f, 0x66, 0x66,
-	0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x46, 0x6c, 0x61, 0x74, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0xd0, 0x3e, 0x05, 0x12, 0x32, 0x0a, 0x11, 0x53, 0x48, 0x4f, 0x57, 0x5f,
-	0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x1a, 0x1b,
-	0xca, 0x3e, 0x15, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x72, 0x6f,
-	0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0xd0, 0x3e, 0x01, 0x12, 0x2b, 0x0a, 0x0d, 0x46,
-	0x52, 0x45, 0x45, 0x5f, 0x53, 0x48, 0x49, 0x50, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x1a, 0x18,
-	0xca, 0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x53, 0x68, 0x69,
-	0x70, 0x70, 0x69, 0x6e, 0x67, 0xd0, 0x3e, 0x02, 0x12, 0x30, 0x0a, 0x12, 0x41, 0x53, 0x53, 0x49,
-	0x47, 0x4e, 0x5f, 0x43, 0x4f, 0x55, 0x50, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x10, 0x04,
-	0x1a, 0x18, 0xca, 0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67,
-	0x6e, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0xd0, 0x3e, 0x01, 0x12, 0x2b, 0x0a, 0x0d, 0x50, 0x52,
-	0x4f, 0x4d, 0x4f, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x05, 0x1a, 0x18, 0xca,
-	0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0xd0, 0x3e, 0x01, 0x12, 0x34, 0x0a, 0x12, 0x41, 0x44, 0x44, 0x5f, 0x4c,
-	0x4f, 0x59, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x53, 0x10, 0x06, 0x1a,
-	0x1c, 0xca, 0x3e, 0x16, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x4c, 0x6f, 0x79,
-	0x61, 0x6c, 0x74, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0xd0, 0x3e, 0x03, 0x2a, 0x7c, 0x0a,
-	0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x46, 0x49,
-	0x45, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
-	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
-	0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x4e,
-	0x47, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x05, 0x12, 0x0a,
-	0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54,
-	0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x07, 0x2a, 0x98, 0x01, 0x0a, 0x09,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x56, 0x41, 0x4c,
-	0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f,
-	0x56, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x56, 0x41,
-	0x4c, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x5f, 0x56,
-	0x41, 0x4c, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x4c, 0x4f, 0x4e, 0x47, 0x5f, 0x56, 0x41, 0x4c,
-	0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10,
-	0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x10,
-	0x06, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54,
-	0x5f, 0x56, 0x41, 0x4c, 0x10, 0x07, 0x2a, 0xb6, 0x02, 0x0a, 0x17, 0x47, 0x52, 0x75, 0x6c, 0x65,
-	0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x12, 0x35, 0x0a, 0x1f, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
-	0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x1a, 0x10, 0xda, 0x3e, 0x0d, 0x20, 0x75, 0x6e, 0x73,
-	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x20, 0x12, 0x15, 0x0a, 0x09, 0x4c, 0x45, 0x53,
-	0x53, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x01, 0x1a, 0x06, 0xda, 0x3e, 0x03, 0x20, 0x3c, 0x20,
-	0x12, 0x1d, 0x0a, 0x10, 0x4c, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x5f, 0x45, 0x51,
-	0x55, 0x41, 0x4c, 0x53, 0x10, 0x02, 0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20, 0x3c, 0x3d, 0x20, 0x12,
-	0x18, 0x0a, 0x0c, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10,
-	0x03, 0x1a, 0x06, 0xda, 0x3e, 0x03, 0x20, 0x3e, 0x20, 0x12, 0x20, 0x0a, 0x13, 0x47, 0x52, 0x45,
-	0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53,
-	0x10, 0x04, 0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20, 0x3e, 0x3d, 0x20, 0x12, 0x13, 0x0a, 0x06, 0x45,
-	0x51, 0x55, 0x41, 0x4c, 0x53, 0x10, 0x05, 0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20, 0x3d, 0x3d, 0x20,
-	0x12, 0x17, 0x0a, 0x0a, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x10, 0x06,
-	0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20, 0x21, 0x3d, 0x20, 0x12, 0x44, 0x0a, 0x15, 0x48, 0x41, 0x53,
-	0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x07, 0x1a, 0x29, 0xda, 0x3e, 0x26, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
-	0x72, 0x2e, 0x48, 0x61, 0x73, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x28, 0x3a, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0x2a,
-	0x67, 0x0a, 0x11, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x12, 0x2f, 0x0a, 0x19, 0x4a, 0x4f, 0x49, 0x4e, 0x5f, 0x4f, 0x50, 0x45,
-	0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x10, 0x00, 0x1a, 0x10, 0xda, 0x3e, 0x0d, 0x20, 0x75, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x69,
-	0x66, 0x69, 0x65, 0x64, 0x20, 0x12, 0x10, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x01, 0x1a, 0x07,
-	0xda, 0x3e, 0x04, 0x20, 0x26, 0x26, 0x20, 0x12, 0x0f, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x02, 0x1a,
-	0x07, 0xda, 0x3e, 0x04, 0x20, 0x7c, 0x7c, 0x20, 0x3a, 0x48, 0x0a, 0x0e, 0x67, 0x72, 0x6c, 0x5f,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75,
-	0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe9, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x72, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61,
-	0x6d, 0x65, 0x3a, 0x67, 0x0a, 0x0e, 0x67, 0x72, 0x6c, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xea, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d,
+	0x2e, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65,
+	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x70, 0x65, 0x72, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x76, 0x65, 0x22, 0xa9, 0x18, 0x0a, 0x12, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63,
+	0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x73, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5d,
+	0x0a, 0x17, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x69, 0x6e,
+	0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x25, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x47, 0x0a,
+	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x53, 0x0a, 0x10, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x28, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x54, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x74, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x48, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x72, 0x65, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d,
+	0x12, 0x3b, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x3c, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0xed, 0x0b, 0x0a, 0x09,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x0b, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b,
 	0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75,
-	0x6c, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x67,
-	0x72, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x3a, 0x45, 0x0a, 0x0c, 0x67,
-	0x72, 0x6c, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x2e, 0x67, 0x6f,
+	0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x5f, 0x0a, 0x18, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e,
+	0x47, 0x52, 0x75, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x16, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4a, 0x6f, 0x69,
+	0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x9f, 0x02, 0x0a, 0x0a, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x47, 0x0a, 0x08, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e,
+	0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x65,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0xfd, 0x07, 0x0a, 0x0a,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x03, 0x41, 0x47,
+	0x45, 0x10, 0x00, 0x1a, 0x12, 0xca, 0x3e, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x2e, 0x41, 0x67, 0x65, 0xd0, 0x3e, 0x03, 0x12, 0x21, 0x0a, 0x06, 0x47, 0x45, 0x4e, 0x44, 0x45,
+	0x52, 0x10, 0x01, 0x1a, 0x15, 0xca, 0x3e, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72,
+	0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0xd0, 0x3e, 0x01, 0x12, 0x25, 0x0a, 0x08, 0x4c, 0x4f,
+	0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x1a, 0x17, 0xca, 0x3e, 0x11, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0xd0, 0x3e,
+	0x01, 0x12, 0x2a, 0x0a, 0x0b, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x10, 0x03, 0x1a, 0x19, 0xca, 0x3e, 0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0xd0, 0x3e, 0x01, 0x12, 0x44, 0x0a,
+	0x19, 0x49, 0x53, 0x5f, 0x4c, 0x4f, 0x59, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x47,
+	0x52, 0x41, 0x4d, 0x5f, 0x4d, 0x45, 0x4d, 0x42, 0x45, 0x52, 0x10, 0x04, 0x1a, 0x25, 0xca, 0x3e,
+	0x1f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x49, 0x73, 0x4c, 0x6f, 0x79, 0x61,
+	0x6c, 0x74, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0xd0, 0x3e, 0x02, 0x12, 0x36, 0x0a, 0x14, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x5f, 0x4c, 0x49, 0x46,
+	0x45, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x54, 0x10, 0x05, 0x1a, 0x1c, 0xca,
+	0x3e, 0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x53, 0x70, 0x65, 0x6e, 0x74, 0xd0, 0x3e, 0x06, 0xe8, 0x3e, 0x02, 0x12, 0x34, 0x0a, 0x0f, 0x41,
+	0x56, 0x47, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x06,
+	0x1a, 0x1f, 0xca, 0x3e, 0x16, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x41, 0x76,
+	0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0xd0, 0x3e, 0x05, 0xe8, 0x3e,
+	0x02, 0x12, 0x3e, 0x0a, 0x16, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41,
+	0x53, 0x45, 0x5f, 0x44, 0x41, 0x59, 0x53, 0x5f, 0x41, 0x47, 0x4f, 0x10, 0x07, 0x1a, 0x22, 0xca,
+	0x3e, 0x1c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x50,
+	0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x44, 0x61, 0x79, 0x73, 0x41, 0x67, 0x6f, 0xd0, 0x3e,
+	0x03, 0x12, 0x41, 0x0a, 0x17, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f,
+	0x52, 0x59, 0x5f, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41, 0x53, 0x45, 0x44, 0x10, 0x08, 0x1a, 0x24,
+	0xca, 0x3e, 0x1e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x73, 0x74,
+	0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65,
+	0x64, 0xd0, 0x3e, 0x01, 0x12, 0x3c, 0x0a, 0x14, 0x50, 0x52, 0x45, 0x46, 0x45, 0x52, 0x52, 0x45,
+	0x44, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45, 0x53, 0x10, 0x09, 0x1a, 0x22,
+	0xca, 0x3e, 0x1c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x72, 0x65, 0x64, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0xd0,
+	0x3e, 0x07, 0x12, 0x2b, 0x0a, 0x0a, 0x43, 0x41, 0x52, 0x54, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c,
+	0x10, 0x0a, 0x1a, 0x1b, 0xca, 0x3e, 0x12, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e,
+	0x43, 0x61, 0x72, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0xd0, 0x3e, 0x05, 0xe8, 0x3e, 0x02, 0x12,
+	0x43, 0x0a, 0x18, 0x43, 0x41, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53,
+	0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45, 0x53, 0x10, 0x0b, 0x1a, 0x25, 0xca,
+	0x3e, 0x1f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x43, 0x61, 0x72, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0xd0, 0x3e, 0x07, 0x12, 0x3a, 0x0a, 0x13, 0x42, 0x52, 0x4f, 0x57, 0x53, 0x49, 0x4e, 0x47,
+	0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45, 0x53, 0x10, 0x0c, 0x1a, 0x21, 0xca,
+	0x3e, 0x1b, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x42, 0x72, 0x6f, 0x77, 0x73,
+	0x69, 0x6e, 0x67, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0xd0, 0x3e, 0x07,
+	0x12, 0x40, 0x0a, 0x1b, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x43, 0x4f, 0x55,
+	0x4e, 0x54, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x33, 0x30, 0x5f, 0x44, 0x41, 0x59, 0x53, 0x10,
+	0x0d, 0x1a, 0x1f, 0xca, 0x3e, 0x19, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x50,
+	0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x33, 0x30, 0x64, 0xd0,
+	0x3e, 0x03, 0x12, 0x39, 0x0a, 0x13, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f, 0x52, 0x41, 0x54,
+	0x45, 0x5f, 0x50, 0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x10, 0x0e, 0x1a, 0x20, 0xca, 0x3e, 0x1a,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52,
+	0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0xd0, 0x3e, 0x05, 0x12, 0x46, 0x0a,
+	0x1a, 0x48, 0x41, 0x53, 0x5f, 0x43, 0x4f, 0x55, 0x50, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x44, 0x45,
+	0x45, 0x4d, 0x45, 0x44, 0x5f, 0x42, 0x45, 0x46, 0x4f, 0x52, 0x45, 0x10, 0x0f, 0x1a, 0x26, 0xca,
+	0x3e, 0x20, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65,
+	0x64, 0x65, 0x65, 0x6d, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x42, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0xd0, 0x3e, 0x02, 0x12, 0x31, 0x0a, 0x0f, 0x53, 0x49, 0x47, 0x4e, 0x55, 0x50, 0x5f,
+	0x44, 0x41, 0x59, 0x53, 0x5f, 0x41, 0x47, 0x4f, 0x10, 0x10, 0x1a, 0x1c, 0xca, 0x3e, 0x16, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x44, 0x61,
+	0x79, 0x73, 0x41, 0x67, 0x6f, 0xd0, 0x3e, 0x03, 0x12, 0x41, 0x0a, 0x17, 0x4c, 0x49, 0x46, 0x45,
+	0x54, 0x49, 0x4d, 0x45, 0x5f, 0x4c, 0x4f, 0x59, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x49,
+	0x4e, 0x54, 0x53, 0x10, 0x11, 0x1a, 0x24, 0xca, 0x3e, 0x1e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x2e, 0x4c, 0x69, 0x66, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x79, 0x61, 0x6c,
+	0x74, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0xd0, 0x3e, 0x04, 0x1a, 0x81, 0x07, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x39, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x52, 0x75,
+	0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x52,
+	0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x36, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x22, 0xa6, 0x03, 0x0a, 0x0b, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x3f, 0x0a, 0x16, 0x41, 0x50, 0x50, 0x4c, 0x59, 0x5f, 0x44, 0x49, 0x53, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x50, 0x45, 0x52, 0x43, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x1a, 0x23,
+	0xca, 0x3e, 0x1a, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0xd0, 0x3e, 0x05,
+	0xe8, 0x3e, 0x02, 0x12, 0x39, 0x0a, 0x13, 0x41, 0x50, 0x50, 0x4c, 0x59, 0x5f, 0x46, 0x4c, 0x41,
+	0x54, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x1a, 0x20, 0xca, 0x3e,
+	0x17, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x46, 0x6c, 0x61, 0x74,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0xd0, 0x3e, 0x05, 0xe8, 0x3e, 0x02, 0x12, 0x32,
+	0x0a, 0x11, 0x53, 0x48, 0x4f, 0x57, 0x5f, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x49, 0x44, 0x10, 0x02, 0x1a, 0x1b, 0xca, 0x3e, 0x15, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e,
+	0x53, 0x68, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0xd0,
+	0x3e, 0x01, 0x12, 0x2b, 0x0a, 0x0d, 0x46, 0x52, 0x45, 0x45, 0x5f, 0x53, 0x48, 0x49, 0x50, 0x50,
+	0x49, 0x4e, 0x47, 0x10, 0x03, 0x1a, 0x18, 0xca, 0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e,
+	0x46, 0x72, 0x65, 0x65, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0xd0, 0x3e, 0x02, 0x12,
+	0x30, 0x0a, 0x12, 0x41, 0x53, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x43, 0x4f, 0x55, 0x50, 0x4f, 0x4e,
+	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x10, 0x04, 0x1a, 0x18, 0xca, 0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0xd0, 0x3e,
+	0x01, 0x12, 0x2b, 0x0a, 0x0d, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41,
+	0x47, 0x45, 0x10, 0x05, 0x1a, 0x18, 0xca, 0x3e, 0x12, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0xd0, 0x3e, 0x01, 0x12, 0x34,
+	0x0a, 0x12, 0x41, 0x44, 0x44, 0x5f, 0x4c, 0x4f, 0x59, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x4f,
+	0x49, 0x4e, 0x54, 0x53, 0x10, 0x06, 0x1a, 0x1c, 0xca, 0x3e, 0x16, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x2e, 0x41, 0x64, 0x64, 0x4c, 0x6f, 0x79, 0x61, 0x6c, 0x74, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0xd0, 0x3e, 0x03, 0x12, 0x25, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x5f, 0x54, 0x41,
+	0x47, 0x53, 0x10, 0x07, 0x1a, 0x15, 0xca, 0x3e, 0x0f, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x54, 0x61, 0x67, 0x73, 0xd0, 0x3e, 0x07, 0x22, 0xf1, 0x01, 0x0a, 0x08,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x03, 0x53, 0x45, 0x54, 0x10,
+	0x00, 0x1a, 0x0f, 0xda, 0x3e, 0x03, 0x20, 0x3d, 0x20, 0xe2, 0x3e, 0x06, 0x01, 0x02, 0x03, 0x04,
+	0x05, 0x06, 0x12, 0x17, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x01, 0x1a, 0x0e, 0xda, 0x3e, 0x04,
+	0x20, 0x2b, 0x3d, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x1c, 0x0a, 0x08, 0x53,
+	0x55, 0x42, 0x54, 0x52, 0x41, 0x43, 0x54, 0x10, 0x02, 0x1a, 0x0e, 0xda, 0x3e, 0x04, 0x20, 0x2d,
+	0x3d, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x2f, 0x0a, 0x03, 0x4d, 0x41, 0x58,
+	0x10, 0x03, 0x1a, 0x26, 0xda, 0x3e, 0x1c, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x4d, 0x61,
+	0x78, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x29, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x2f, 0x0a, 0x03, 0x4d, 0x49,
+	0x4e, 0x10, 0x04, 0x1a, 0x26, 0xda, 0x3e, 0x1c, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x4d,
+	0x69, 0x6e, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x32, 0x0a, 0x06, 0x41,
+	0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x05, 0x1a, 0x26, 0xda, 0x3e, 0x1f, 0x48, 0x65, 0x6c, 0x70,
+	0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x01, 0x07, 0x22,
+	0x92, 0x01, 0x0a, 0x0c, 0x52, 0x75, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x6f, 0x74, 0x65, 0x73, 0x22, 0xb6, 0x03, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x5d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e,
+	0x45, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x75,
+	0x6c, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x35, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x3a, 0x0a,
+	0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x48, 0x00, 0x52,
+	0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x1a, 0x89, 0x01, 0x0a, 0x05, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x41, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x47, 0x52, 0x75, 0x6c, 0x65,
+	0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0xd6, 0x01,
+	0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x64, 0x61,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x57, 0x65,
+	0x65, 0x6b, 0x64, 0x61, 0x79, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x47, 0x0a, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e,
+	0x65, 0x1a, 0x33, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x2a, 0x7c, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42,
+	0x4f, 0x4f, 0x4c, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52,
+	0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05,
+	0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c,
+	0x45, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49,
+	0x53, 0x54, 0x10, 0x07, 0x2a, 0xad, 0x02, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0c,
+	0x0a, 0x08, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b,
+	0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x0c, 0x0a,
+	0x08, 0x4c, 0x4f, 0x4e, 0x47, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x46,
+	0x4c, 0x4f, 0x41, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x4f,
+	0x55, 0x42, 0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x06, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54,
+	0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x07, 0x12,
+	0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x49, 0x4d, 0x41, 0x4c, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x08,
+	0x12, 0x0d, 0x0a, 0x09, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x09, 0x12,
+	0x11, 0x0a, 0x0d, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x52, 0x45, 0x46, 0x5f, 0x56, 0x41, 0x4c,
+	0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x45, 0x54, 0x49, 0x43,
+	0x5f, 0x56, 0x41, 0x4c, 0x10, 0x0b, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x54, 0x5f, 0x4c, 0x49,
+	0x53, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x0c, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x4c, 0x4f, 0x41,
+	0x54, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x0d, 0x12, 0x11, 0x0a, 0x0d,
+	0x4c, 0x4f, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x56, 0x41, 0x4c, 0x10, 0x0e, 0x12,
+	0x13, 0x0a, 0x0f, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x56,
+	0x41, 0x4c, 0x10, 0x0f, 0x2a, 0x8b, 0x08, 0x0a, 0x17, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x45, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x35, 0x0a, 0x1f, 0x45, 0x58, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x1a, 0x10, 0xda, 0x3e, 0x0d, 0x20, 0x75, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x20, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x45, 0x53, 0x53, 0x5f,
+	0x54, 0x48, 0x41, 0x4e, 0x10, 0x01, 0x1a, 0x0d, 0xda, 0x3e, 0x03, 0x20, 0x3c, 0x20, 0xe2, 0x3e,
+	0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x24, 0x0a, 0x10, 0x4c, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x48,
+	0x41, 0x4e, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x10, 0x02, 0x1a, 0x0e, 0xda, 0x3e, 0x04,
+	0x20, 0x3c, 0x3d, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x1f, 0x0a, 0x0c, 0x47,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x10, 0x03, 0x1a, 0x0d, 0xda,
+	0x3e, 0x03, 0x20, 0x3e, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x27, 0x0a, 0x13,
+	0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x48, 0x41, 0x4e, 0x5f, 0x45, 0x51, 0x55,
+	0x41, 0x4c, 0x53, 0x10, 0x04, 0x1a, 0x0e, 0xda, 0x3e, 0x04, 0x20, 0x3e, 0x3d, 0x20, 0xe2, 0x3e,
+	0x04, 0x03, 0x04, 0x05, 0x06, 0x12, 0x1c, 0x0a, 0x06, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x10,
+	0x05, 0x1a, 0x10, 0xda, 0x3e, 0x04, 0x20, 0x3d, 0x3d, 0x20, 0xe2, 0x3e, 0x06, 0x01, 0x02, 0x03,
+	0x04, 0x05, 0x06, 0x12, 0x20, 0x0a, 0x0a, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c,
+	0x53, 0x10, 0x06, 0x1a, 0x10, 0xda, 0x3e, 0x04, 0x20, 0x21, 0x3d, 0x20, 0xe2, 0x3e, 0x06, 0x01,
+	0x02, 0x03, 0x04, 0x05, 0x06, 0x12, 0x48, 0x0a, 0x15, 0x48, 0x41, 0x53, 0x5f, 0x43, 0x41, 0x54,
+	0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x07,
+	0x1a, 0x2d, 0xda, 0x3e, 0x26, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x48, 0x61,
+	0x73, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x01, 0x07, 0x12,
+	0x2e, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x08, 0x1a, 0x20, 0xda,
+	0x3e, 0x19, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x73, 0x28, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x01, 0x01, 0x12,
+	0x32, 0x0a, 0x0b, 0x53, 0x54, 0x41, 0x52, 0x54, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x10, 0x09,
+	0x1a, 0x21, 0xda, 0x3e, 0x1a, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x48, 0x61, 0x73, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x28, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2,
+	0x3e, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x09, 0x45, 0x4e, 0x44, 0x53, 0x5f, 0x57, 0x49, 0x54, 0x48,
+	0x10, 0x0a, 0x1a, 0x21, 0xda, 0x3e, 0x1a, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x48, 0x61,
+	0x73, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78, 0x28, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x29, 0xe2, 0x3e, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x0d, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x45, 0x53,
+	0x5f, 0x52, 0x45, 0x47, 0x45, 0x58, 0x10, 0x0b, 0x1a, 0x23, 0xda, 0x3e, 0x1c, 0x3a, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x28,
+	0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x01, 0x01, 0x12, 0x3b, 0x0a,
+	0x12, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x53, 0x5f, 0x49, 0x47, 0x4e, 0x4f, 0x52, 0x45, 0x5f, 0x43,
+	0x41, 0x53, 0x45, 0x10, 0x0c, 0x1a, 0x23, 0xda, 0x3e, 0x1c, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x2e, 0x54, 0x6f, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x28, 0x29, 0x20, 0x3d, 0x3d, 0x20, 0x3a, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0xe2, 0x3e, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x02, 0x49, 0x4e,
+	0x10, 0x0d, 0x1a, 0x26, 0xda, 0x3e, 0x1b, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x49, 0x6e,
+	0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x29, 0xe2, 0x3e, 0x05, 0x01, 0x03, 0x04, 0x05, 0x06, 0x12, 0x33, 0x0a, 0x06, 0x4e, 0x4f,
+	0x54, 0x5f, 0x49, 0x4e, 0x10, 0x0e, 0x1a, 0x27, 0xda, 0x3e, 0x1c, 0x21, 0x48, 0x65, 0x6c, 0x70,
+	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x05, 0x01, 0x03, 0x04, 0x05, 0x06, 0x12,
+	0x4a, 0x0a, 0x12, 0x48, 0x41, 0x53, 0x5f, 0x41, 0x4c, 0x4c, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47,
+	0x4f, 0x52, 0x49, 0x45, 0x53, 0x10, 0x0f, 0x1a, 0x32, 0xda, 0x3e, 0x2b, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x2e, 0x48, 0x61, 0x73, 0x41, 0x6c, 0x6c, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x29, 0xe2, 0x3e, 0x01, 0x07, 0x12, 0x48, 0x0a, 0x11, 0x48,
+	0x41, 0x53, 0x5f, 0x4e, 0x4f, 0x5f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x49, 0x45, 0x53,
+	0x10, 0x10, 0x1a, 0x31, 0xda, 0x3e, 0x2a, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x2e,
+	0x48, 0x61, 0x73, 0x4e, 0x6f, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x28,
+	0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x2c, 0x20, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x29, 0xe2, 0x3e, 0x01, 0x07, 0x12, 0x2b, 0x0a, 0x08, 0x49, 0x53, 0x5f, 0x45, 0x4d, 0x50, 0x54,
+	0x59, 0x10, 0x11, 0x1a, 0x1d, 0xda, 0x3e, 0x16, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x49,
+	0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x29, 0xe2, 0x3e,
+	0x01, 0x07, 0x12, 0x30, 0x0a, 0x0c, 0x49, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4d, 0x50,
+	0x54, 0x59, 0x10, 0x12, 0x1a, 0x1e, 0xda, 0x3e, 0x17, 0x21, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72,
+	0x2e, 0x49, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x28, 0x3a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x29,
+	0xe2, 0x3e, 0x01, 0x07, 0x12, 0x1b, 0x0a, 0x07, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e, 0x10,
+	0x13, 0x1a, 0x0e, 0xda, 0x3e, 0x04, 0x20, 0x26, 0x26, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04, 0x05,
+	0x06, 0x12, 0x1f, 0x0a, 0x0b, 0x4e, 0x4f, 0x54, 0x5f, 0x42, 0x45, 0x54, 0x57, 0x45, 0x45, 0x4e,
+	0x10, 0x14, 0x1a, 0x0e, 0xda, 0x3e, 0x04, 0x20, 0x7c, 0x7c, 0x20, 0xe2, 0x3e, 0x04, 0x03, 0x04,
+	0x05, 0x06, 0x2a, 0x56, 0x0a, 0x0e, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x29, 0x0a, 0x05, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01, 0x1a, 0x1e, 0xd0, 0x3e, 0x03, 0xda,
+	0x3e, 0x14, 0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x28, 0x3a,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x29, 0xe2, 0x3e, 0x01, 0x07, 0x2a, 0x67, 0x0a, 0x11, 0x47, 0x52,
+	0x75, 0x6c, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x2f, 0x0a, 0x19, 0x4a, 0x4f, 0x49, 0x4e, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x1a, 0x10,
+	0xda, 0x3e, 0x0d, 0x20, 0x75, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x20,
+	0x12, 0x10, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x01, 0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20, 0x26,
+	0x26, 0x20, 0x12, 0x0f, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x02, 0x1a, 0x07, 0xda, 0x3e, 0x04, 0x20,
+	0x7c, 0x7c, 0x20, 0x2a, 0xce, 0x01, 0x0a, 0x12, 0x41, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x65, 0x74,
+	0x69, 0x63, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x52,
+	0x49, 0x54, 0x48, 0x4d, 0x45, 0x54, 0x49, 0x43, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f,
+	0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0f, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x01, 0x1a, 0x06, 0xda, 0x3e, 0x03, 0x20, 0x2b, 0x20,
+	0x12, 0x14, 0x0a, 0x08, 0x53, 0x55, 0x42, 0x54, 0x52, 0x41, 0x43, 0x54, 0x10, 0x02, 0x1a, 0x06,
+	0xda, 0x3e, 0x03, 0x20, 0x2d, 0x20, 0x12, 0x14, 0x0a, 0x08, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50,
+	0x4c, 0x59, 0x10, 0x03, 0x1a, 0x06, 0xda, 0x3e, 0x03, 0x20, 0x2a, 0x20, 0x12, 0x12, 0x0a, 0x06,
+	0x44, 0x49, 0x56, 0x49, 0x44, 0x45, 0x10, 0x04, 0x1a, 0x06, 0xda, 0x3e, 0x03, 0x20, 0x2f, 0x20,
+	0x12, 0x20, 0x0a, 0x03, 0x4d, 0x49, 0x4e, 0x10, 0x05, 0x1a, 0x17, 0xda, 0x3e, 0x14, 0x48, 0x65,
+	0x6c, 0x70, 0x65, 0x72, 0x2e, 0x4d, 0x69, 0x6e, 0x28, 0x3a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x29, 0x12, 0x20, 0x0a, 0x03, 0x4d, 0x41, 0x58, 0x10, 0x06, 0x1a, 0x17, 0xda, 0x3e, 0x14,
+	0x48, 0x65, 0x6c, 0x70, 0x65, 0x72, 0x2e, 0x4d, 0x61, 0x78, 0x28, 0x3a, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x29, 0x2a, 0x49, 0x0a, 0x14, 0x47, 0x52, 0x75, 0x6c, 0x65, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x0c,
+	0x52, 0x45, 0x54, 0x52, 0x41, 0x43, 0x54, 0x5f, 0x53, 0x45, 0x4c, 0x46, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x52, 0x45, 0x46, 0x49, 0x52, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x2a,
+	0x7e, 0x0a, 0x07, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x17, 0x0a, 0x13, 0x57, 0x45,
+	0x45, 0x4b, 0x44, 0x41, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x54, 0x55, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
+	0x57, 0x45, 0x44, 0x4e, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x54,
+	0x48, 0x55, 0x52, 0x53, 0x44, 0x41, 0x59, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x52, 0x49,
+	0x44, 0x41, 0x59, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x41, 0x54, 0x55, 0x52, 0x44, 0x41,
+	0x59, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x55, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x07, 0x3a,
+	0x48, 0x0a, 0x0e, 0x67, 0x72, 0x6c, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe9, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x72, 0x6c,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x3a, 0x67, 0x0a, 0x0e, 0x67, 0x72, 0x6c,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e,
-	0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xeb,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2e, 0x2f, 0x64, 0x73, 0x6c, 0x3b, 0x64, 0x73, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xea,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x67, 0x72, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x3a, 0x45, 0x0a, 0x0c, 0x67, 0x72, 0x6c, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xeb, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72,
+	0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x3a, 0x6d, 0x0a, 0x11, 0x67, 0x72, 0x6c,
+	0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xec, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0f, 0x67, 0x72, 0x6c, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x3a, 0x47, 0x0a, 0x0d, 0x67, 0x72, 0x6c, 0x5f,
+	0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xed, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x67, 0x72, 0x6c, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2e, 0x2f, 0x64, 0x73, 0x6c, 0x3b, 0x64, 0x73, 0x6c, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -1090,42 +2732,90 @@ func file_ecommerce_offer_rules_proto_rawDescGZIP() []byte {
 	return file_ecommerce_offer_rules_proto_rawDescData
 }
 
-var file_ecommerce_offer_rules_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_ecommerce_offer_rules_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ecommerce_offer_rules_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_ecommerce_offer_rules_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_ecommerce_offer_rules_proto_goTypes = []any{
 	(FieldType)(0),                                  // 0: ecommerce.v1.rules.FieldType
 	(ValueType)(0),                                  // 1: ecommerce.v1.rules.ValueType
 	(GRuleExpressionOperator)(0),                    // 2: ecommerce.v1.rules.GRuleExpressionOperator
-	(GRuleJoinOperator)(0),                          // 3: ecommerce.v1.rules.GRuleJoinOperator
-	(EcommerceOfferRule_Condition_InputField)(0),    // 4: ecommerce.v1.rules.EcommerceOfferRule.Condition.InputField
-	(EcommerceOfferRule_Action_OutputField)(0),      // 5: ecommerce.v1.rules.EcommerceOfferRule.Action.OutputField
-	(*RuleValue)(nil),                               // 6: ecommerce.v1.rules.RuleValue
-	(*EcommerceOfferRule)(nil),                      // 7: ecommerce.v1.rules.EcommerceOfferRule
-	(*EcommerceOfferRule_Condition)(nil),            // 8: ecommerce.v1.rules.EcommerceOfferRule.Condition
-	(*EcommerceOfferRule_Action)(nil),               // 9: ecommerce.v1.rules.EcommerceOfferRule.Action
-	(*EcommerceOfferRule_Condition_Expression)(nil), // 10: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression
-	(*descriptorpb.EnumValueOptions)(nil),           // 11: google.protobuf.EnumValueOptions
+	(GRuleAggregate)(0),                             // 3: ecommerce.v1.rules.GRuleAggregate
+	(GRuleJoinOperator)(0),                          // 4: ecommerce.v1.rules.GRuleJoinOperator
+	(ArithmeticOperator)(0),                         // 5: ecommerce.v1.rules.ArithmeticOperator
+	(GRuleTerminationMode)(0),                       // 6: ecommerce.v1.rules.GRuleTerminationMode
+	(Weekday)(0),                                    // 7: ecommerce.v1.rules.Weekday
+	(EcommerceOfferRule_Condition_InputField)(0),    // 8: ecommerce.v1.rules.EcommerceOfferRule.Condition.InputField
+	(EcommerceOfferRule_Action_OutputField)(0),      // 9: ecommerce.v1.rules.EcommerceOfferRule.Action.OutputField
+	(EcommerceOfferRule_Action_Operator)(0),         // 10: ecommerce.v1.rules.EcommerceOfferRule.Action.Operator
+	(*RuleValue)(nil),                               // 11: ecommerce.v1.rules.RuleValue
+	(*StringList)(nil),                              // 12: ecommerce.v1.rules.StringList
+	(*IntList)(nil),                                 // 13: ecommerce.v1.rules.IntList
+	(*FloatList)(nil),                               // 14: ecommerce.v1.rules.FloatList
+	(*LongList)(nil),                                // 15: ecommerce.v1.rules.LongList
+	(*DoubleList)(nil),                              // 16: ecommerce.v1.rules.DoubleList
+	(*Arithmetic)(nil),                              // 17: ecommerce.v1.rules.Arithmetic
+	(*Range)(nil),                                   // 18: ecommerce.v1.rules.Range
+	(*EcommerceOfferRule)(nil),                      // 19: ecommerce.v1.rules.EcommerceOfferRule
+	(*RuleMetadata)(nil),                            // 20: ecommerce.v1.rules.RuleMetadata
+	(*ConditionNode)(nil),                           // 21: ecommerce.v1.rules.ConditionNode
+	(*Schedule)(nil),                                // 22: ecommerce.v1.rules.Schedule
+	(*EcommerceOfferRule_Condition)(nil),            // 23: ecommerce.v1.rules.EcommerceOfferRule.Condition
+	(*EcommerceOfferRule_Action)(nil),               // 24: ecommerce.v1.rules.EcommerceOfferRule.Action
+	(*EcommerceOfferRule_Condition_Expression)(nil), // 25: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression
+	(*ConditionNode_Group)(nil),                     // 26: ecommerce.v1.rules.ConditionNode.Group
+	(*Schedule_TimeRange)(nil),                      // 27: ecommerce.v1.rules.Schedule.TimeRange
+	(*timestamppb.Timestamp)(nil),                   // 28: google.protobuf.Timestamp
+	(*descriptorpb.EnumValueOptions)(nil),           // 29: google.protobuf.EnumValueOptions
 }
 var file_ecommerce_offer_rules_proto_depIdxs = []int32{
-	8,  // 0: ecommerce.v1.rules.EcommerceOfferRule.conditions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition
-	3,  // 1: ecommerce.v1.rules.EcommerceOfferRule.condition_join_operator:type_name -> ecommerce.v1.rules.GRuleJoinOperator
-	9,  // 2: ecommerce.v1.rules.EcommerceOfferRule.actions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Action
-	10, // 3: ecommerce.v1.rules.EcommerceOfferRule.Condition.expressions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression
-	3,  // 4: ecommerce.v1.rules.EcommerceOfferRule.Condition.expression_join_operator:type_name -> ecommerce.v1.rules.GRuleJoinOperator
-	5,  // 5: ecommerce.v1.rules.EcommerceOfferRule.Action.output:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Action.OutputField
-	6,  // 6: ecommerce.v1.rules.EcommerceOfferRule.Action.value:type_name -> ecommerce.v1.rules.RuleValue
-	4,  // 7: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.input:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.InputField
-	2,  // 8: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.operator:type_name -> ecommerce.v1.rules.GRuleExpressionOperator
-	6,  // 9: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.value:type_name -> ecommerce.v1.rules.RuleValue
-	11, // 10: ecommerce.v1.rules.grl_field_name:extendee -> google.protobuf.EnumValueOptions
-	11, // 11: ecommerce.v1.rules.grl_field_type:extendee -> google.protobuf.EnumValueOptions
-	11, // 12: ecommerce.v1.rules.grl_operator:extendee -> google.protobuf.EnumValueOptions
-	0,  // 13: ecommerce.v1.rules.grl_field_type:type_name -> ecommerce.v1.rules.FieldType
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	13, // [13:14] is the sub-list for extension type_name
-	10, // [10:13] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	18, // 0: ecommerce.v1.rules.RuleValue.range_val:type_name -> ecommerce.v1.rules.Range
+	8,  // 1: ecommerce.v1.rules.RuleValue.field_ref:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.InputField
+	17, // 2: ecommerce.v1.rules.RuleValue.arithmetic_val:type_name -> ecommerce.v1.rules.Arithmetic
+	12, // 3: ecommerce.v1.rules.RuleValue.string_list:type_name -> ecommerce.v1.rules.StringList
+	13, // 4: ecommerce.v1.rules.RuleValue.int_list:type_name -> ecommerce.v1.rules.IntList
+	14, // 5: ecommerce.v1.rules.RuleValue.float_list:type_name -> ecommerce.v1.rules.FloatList
+	15, // 6: ecommerce.v1.rules.RuleValue.long_list:type_name -> ecommerce.v1.rules.LongList
+	16, // 7: ecommerce.v1.rules.RuleValue.double_list:type_name -> ecommerce.v1.rules.DoubleList
+	5,  // 8: ecommerce.v1.rules.Arithmetic.operator:type_name -> ecommerce.v1.rules.ArithmeticOperator
+	11, // 9: ecommerce.v1.rules.Arithmetic.operands:type_name -> ecommerce.v1.rules.RuleValue
+	11, // 10: ecommerce.v1.rules.Range.lower:type_name -> ecommerce.v1.rules.RuleValue
+	11, // 11: ecommerce.v1.rules.Range.upper:type_name -> ecommerce.v1.rules.RuleValue
+	23, // 12: ecommerce.v1.rules.EcommerceOfferRule.conditions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition
+	4,  // 13: ecommerce.v1.rules.EcommerceOfferRule.condition_join_operator:type_name -> ecommerce.v1.rules.GRuleJoinOperator
+	24, // 14: ecommerce.v1.rules.EcommerceOfferRule.actions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Action
+	6,  // 15: ecommerce.v1.rules.EcommerceOfferRule.termination_mode:type_name -> ecommerce.v1.rules.GRuleTerminationMode
+	21, // 16: ecommerce.v1.rules.EcommerceOfferRule.condition_tree:type_name -> ecommerce.v1.rules.ConditionNode
+	28, // 17: ecommerce.v1.rules.EcommerceOfferRule.valid_from:type_name -> google.protobuf.Timestamp
+	28, // 18: ecommerce.v1.rules.EcommerceOfferRule.valid_until:type_name -> google.protobuf.Timestamp
+	20, // 19: ecommerce.v1.rules.EcommerceOfferRule.metadata:type_name -> ecommerce.v1.rules.RuleMetadata
+	25, // 20: ecommerce.v1.rules.ConditionNode.expression:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression
+	26, // 21: ecommerce.v1.rules.ConditionNode.group:type_name -> ecommerce.v1.rules.ConditionNode.Group
+	21, // 22: ecommerce.v1.rules.ConditionNode.not:type_name -> ecommerce.v1.rules.ConditionNode
+	22, // 23: ecommerce.v1.rules.ConditionNode.schedule:type_name -> ecommerce.v1.rules.Schedule
+	7,  // 24: ecommerce.v1.rules.Schedule.days:type_name -> ecommerce.v1.rules.Weekday
+	27, // 25: ecommerce.v1.rules.Schedule.time_ranges:type_name -> ecommerce.v1.rules.Schedule.TimeRange
+	25, // 26: ecommerce.v1.rules.EcommerceOfferRule.Condition.expressions:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression
+	4,  // 27: ecommerce.v1.rules.EcommerceOfferRule.Condition.expression_join_operator:type_name -> ecommerce.v1.rules.GRuleJoinOperator
+	9,  // 28: ecommerce.v1.rules.EcommerceOfferRule.Action.output:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Action.OutputField
+	11, // 29: ecommerce.v1.rules.EcommerceOfferRule.Action.value:type_name -> ecommerce.v1.rules.RuleValue
+	10, // 30: ecommerce.v1.rules.EcommerceOfferRule.Action.operator:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Action.Operator
+	8,  // 31: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.input:type_name -> ecommerce.v1.rules.EcommerceOfferRule.Condition.InputField
+	2,  // 32: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.operator:type_name -> ecommerce.v1.rules.GRuleExpressionOperator
+	11, // 33: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.value:type_name -> ecommerce.v1.rules.RuleValue
+	3,  // 34: ecommerce.v1.rules.EcommerceOfferRule.Condition.Expression.aggregate:type_name -> ecommerce.v1.rules.GRuleAggregate
+	4,  // 35: ecommerce.v1.rules.ConditionNode.Group.operator:type_name -> ecommerce.v1.rules.GRuleJoinOperator
+	21, // 36: ecommerce.v1.rules.ConditionNode.Group.children:type_name -> ecommerce.v1.rules.ConditionNode
+	29, // 37: ecommerce.v1.rules.grl_field_name:extendee -> google.protobuf.EnumValueOptions
+	29, // 38: ecommerce.v1.rules.grl_field_type:extendee -> google.protobuf.EnumValueOptions
+	29, // 39: ecommerce.v1.rules.grl_operator:extendee -> google.protobuf.EnumValueOptions
+	29, // 40: ecommerce.v1.rules.grl_operand_types:extendee -> google.protobuf.EnumValueOptions
+	29, // 41: ecommerce.v1.rules.grl_precision:extendee -> google.protobuf.EnumValueOptions
+	0,  // 42: ecommerce.v1.rules.grl_field_type:type_name -> ecommerce.v1.rules.FieldType
+	0,  // 43: ecommerce.v1.rules.grl_operand_types:type_name -> ecommerce.v1.rules.FieldType
+	44, // [44:44] is the sub-list for method output_type
+	44, // [44:44] is the sub-list for method input_type
+	42, // [42:44] is the sub-list for extension type_name
+	37, // [37:42] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_ecommerce_offer_rules_proto_init() }
@@ -1139,15 +2829,32 @@ func file_ecommerce_offer_rules_proto_init() {
 		(*RuleValue_IntVal)(nil),
 		(*RuleValue_FloatVal)(nil),
 		(*RuleValue_StringListCommaConcatenated)(nil),
+		(*RuleValue_DecimalVal)(nil),
+		(*RuleValue_RangeVal)(nil),
+		(*RuleValue_FieldRef)(nil),
+		(*RuleValue_ArithmeticVal)(nil),
+		(*RuleValue_StringList)(nil),
+		(*RuleValue_IntList)(nil),
+		(*RuleValue_FloatList)(nil),
+		(*RuleValue_LongVal)(nil),
+		(*RuleValue_DoubleVal)(nil),
+		(*RuleValue_LongList)(nil),
+		(*RuleValue_DoubleList)(nil),
+	}
+	file_ecommerce_offer_rules_proto_msgTypes[10].OneofWrappers = []any{
+		(*ConditionNode_Expression)(nil),
+		(*ConditionNode_Group_)(nil),
+		(*ConditionNode_Not)(nil),
+		(*ConditionNode_Schedule)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ecommerce_offer_rules_proto_rawDesc), len(file_ecommerce_offer_rules_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   5,
-			NumExtensions: 3,
+			NumEnums:      11,
+			NumMessages:   17,
+			NumExtensions: 5,
 			NumServices:   0,
 		},
 		GoTypes:           file_ecommerce_offer_rules_proto_goTypes,
@@ -3,6 +3,7 @@ module grule-protobuf-dsl
 go 1.24
 
 require (
+	github.com/hyperjumptech/grule-rule-engine v1.15.0
 	github.com/stretchr/testify v1.10.0
 	google.golang.org/protobuf v1.36.5
 )
@@ -23,7 +24,6 @@ require (
 	github.com/go-git/go-git/v5 v5.11.0 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/google/uuid v1.3.0 // indirect
-	github.com/hyperjumptech/grule-rule-engine v1.15.0 // indirect
 	github.com/hyperjumptech/hyper-mux v1.1.0 // indirect
 	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
 	github.com/kevinburke/ssh_config v1.2.0 // indirect
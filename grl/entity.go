@@ -1,9 +1,20 @@
 package grl
 
+// Termination values of a GRuleEntity, mirroring GRuleTerminationMode. An
+// empty Termination retracts the rule.
+const (
+	TerminationRetractSelf    = "retract_self"
+	TerminationRefire         = "refire"
+	TerminationCompleteEngine = "complete_engine"
+)
+
 type GRuleEntity struct {
 	Name        string   `json:"name,omitempty"`
 	Description string   `json:"description,omitempty"`
 	When        string   `json:"when,omitempty"`
 	Then        []string `json:"then,omitempty"`
 	Salience    string   `json:"salience,omitempty"`
+	Termination string   `json:"termination,omitempty"`
+	// Comments are written as `// ` line comments above the rule.
+	Comments []string `json:"comments,omitempty"`
 }
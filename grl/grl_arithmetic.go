@@ -0,0 +1,119 @@
+package grl
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// numericFieldTypes are the field types arithmetic operates on and can be
+// compared with or assigned to, when they accept the type it computes.
+var numericFieldTypes = map[dsl.FieldType]bool{
+	dsl.FieldType_INTEGER: true,
+	dsl.FieldType_LONG:    true,
+	dsl.FieldType_FLOAT:   true,
+	dsl.FieldType_DOUBLE:  true,
+}
+
+// numericWidening orders the numeric field types from the narrowest to the
+// widest.
+var numericWidening = []dsl.FieldType{dsl.FieldType_INTEGER, dsl.FieldType_LONG, dsl.FieldType_FLOAT, dsl.FieldType_DOUBLE}
+
+// checkArithmetic validates a and infers the type it computes from its
+// literals and the grl_field_type of the fields it references: the widest
+// type of its operands, at least FLOAT for DIVIDE and for MIN and MAX, whose
+// helpers return floats.
+func checkArithmetic(rule, path string, a *dsl.Arithmetic) (dsl.FieldType, error) {
+	prefix := fmt.Sprintf("rule %s: %s:", rule, path)
+	// unspecified and unknown operators have no grl_operator to render
+	if a.Operator.Descriptor().Values().ByNumber(a.Operator.Number()) == nil || getEnumGrlOperator(a.Operator) == "" {
+		return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, fmt.Errorf("%s unsupported arithmetic operator %s", prefix, a.Operator)
+	}
+	if len(a.Operands) < 2 {
+		return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, fmt.Errorf("%s %s needs at least two operands", prefix, a.Operator)
+	}
+	result := dsl.FieldType_INTEGER
+	switch a.Operator {
+	case dsl.ArithmeticOperator_DIVIDE, dsl.ArithmeticOperator_MIN, dsl.ArithmeticOperator_MAX:
+		result = dsl.FieldType_FLOAT
+	}
+	for i, operand := range a.Operands {
+		operandPath := fmt.Sprintf("%s.operands[%d]", path, i)
+		operandType, err := arithmeticOperandType(rule, operandPath, operand)
+		if err != nil {
+			return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, err
+		}
+		if slices.Index(numericWidening, operandType) > slices.Index(numericWidening, result) {
+			result = operandType
+		}
+	}
+	return result, nil
+}
+
+func arithmeticOperandType(rule, path string, val *dsl.RuleValue) (dsl.FieldType, error) {
+	prefix := fmt.Sprintf("rule %s: %s:", rule, path)
+	switch v := val.GetValue().(type) {
+	case *dsl.RuleValue_IntVal:
+		return dsl.FieldType_INTEGER, nil
+	case *dsl.RuleValue_LongVal:
+		return dsl.FieldType_LONG, nil
+	case *dsl.RuleValue_FloatVal:
+		return dsl.FieldType_FLOAT, nil
+	case *dsl.RuleValue_DoubleVal:
+		return dsl.FieldType_DOUBLE, nil
+	case *dsl.RuleValue_DecimalVal:
+		if !decimalPattern.MatchString(v.DecimalVal) {
+			return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, fmt.Errorf("%s %q is not a valid decimal", prefix, v.DecimalVal)
+		}
+		return dsl.FieldType_FLOAT, nil
+	case *dsl.RuleValue_FieldRef:
+		fieldType := getEnumGrlFieldType(v.FieldRef)
+		if !numericFieldTypes[fieldType] {
+			return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, fmt.Errorf("%s %s field %s cannot be used in arithmetic", prefix, fieldType, getEnumGrlFieldName(v.FieldRef))
+		}
+		return fieldType, nil
+	case *dsl.RuleValue_ArithmeticVal:
+		return checkArithmetic(rule, path+".arithmetic_val", v.ArithmeticVal)
+	default:
+		return dsl.FieldType_FIELD_TYPE_UNSPECIFIED, fmt.Errorf("%s %s cannot be used in arithmetic", prefix, ValueTypeOf(val))
+	}
+}
+
+// arithmeticText renders a validated Arithmetic value as GRL. Nested binary
+// operations are parenthesized inside binary operations, so the operands
+// keep their grouping whatever the operator precedence.
+func arithmeticText(a *dsl.Arithmetic) string {
+	template := getEnumGrlOperator(a.Operator)
+	call := strings.Contains(template, ":replace")
+	operands := make([]string, 0, len(a.Operands))
+	for _, operand := range a.Operands {
+		var text string
+		switch v := operand.GetValue().(type) {
+		case *dsl.RuleValue_IntVal:
+			text = strconv.Itoa(int(v.IntVal))
+		case *dsl.RuleValue_LongVal:
+			text = strconv.FormatInt(v.LongVal, 10)
+		case *dsl.RuleValue_FloatVal:
+			text = formatFloat(v.FloatVal)
+		case *dsl.RuleValue_DoubleVal:
+			text = formatDouble(v.DoubleVal)
+		case *dsl.RuleValue_DecimalVal:
+			text = v.DecimalVal
+		case *dsl.RuleValue_FieldRef:
+			text = getEnumGrlFieldName(v.FieldRef)
+		case *dsl.RuleValue_ArithmeticVal:
+			text = arithmeticText(v.ArithmeticVal)
+			if !call && !strings.Contains(getEnumGrlOperator(v.ArithmeticVal.Operator), ":replace") {
+				text = fmt.Sprintf("( %s )", text)
+			}
+		}
+		operands = append(operands, text)
+	}
+	if call {
+		return strings.Replace(template, ":replace", strings.Join(operands, ", "), 1)
+	}
+	return strings.Join(operands, template)
+}
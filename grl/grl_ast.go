@@ -0,0 +1,229 @@
+package grl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Node is any element of a parsed GRL document.
+type Node interface {
+	Position() Pos
+}
+
+// Expr is an expression inside a rule's when clause or then statements.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// Stmt is a single statement of a rule's then clause.
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+// BinaryExpr is an infix operation such as `a && b` or `a > 1`.
+type BinaryExpr struct {
+	OpPos Pos
+	Op    string
+	X     Expr
+	Y     Expr
+}
+
+// UnaryExpr is a prefix operation, `!x` in GRL.
+type UnaryExpr struct {
+	OpPos Pos
+	Op    string
+	X     Expr
+}
+
+// ParenExpr is a parenthesized expression. It is kept in the tree because the
+// serializer uses parentheses to delimit expressions and condition groups.
+type ParenExpr struct {
+	Lparen Pos
+	X      Expr
+}
+
+// Ident is a bare name such as a fact name.
+type Ident struct {
+	NamePos Pos
+	Name    string
+}
+
+// SelectorExpr is a member access, `X.Sel`.
+type SelectorExpr struct {
+	X   Expr
+	Sel *Ident
+}
+
+// CallExpr is a function or method call, `Fun(Args...)`.
+type CallExpr struct {
+	Fun    Expr
+	Lparen Pos
+	Args   []Expr
+}
+
+// IndexExpr is an array or map selector, `X[Index]`.
+type IndexExpr struct {
+	X     Expr
+	Index Expr
+}
+
+// StringLit is a decoded string literal.
+type StringLit struct {
+	ValuePos Pos
+	Value    string
+	Raw      string
+}
+
+// NumberLit is an integer or float literal, kept in its source form.
+type NumberLit struct {
+	ValuePos Pos
+	Raw      string
+	IsFloat  bool
+}
+
+// BoolLit is a true/false literal.
+type BoolLit struct {
+	ValuePos Pos
+	Value    bool
+}
+
+// NilLit is the nil literal.
+type NilLit struct {
+	ValuePos Pos
+}
+
+// AssignStmt is an assignment such as `Offer.FreeShipping = true`.
+type AssignStmt struct {
+	Lhs   Expr
+	OpPos Pos
+	Op    string
+	Rhs   Expr
+}
+
+// ExprStmt is a bare expression statement, usually a call such as `Retract("x")`.
+type ExprStmt struct {
+	X Expr
+}
+
+// Comment is a `// ...` line comment. Text excludes the slashes.
+type Comment struct {
+	Slash Pos
+	Text  string
+}
+
+// RuleDecl is a single `rule ... { when ... then ... }` entry.
+type RuleDecl struct {
+	RulePos Pos
+	// Comments are the line comments between the previous rule, or the start
+	// of the source, and the rule keyword.
+	Comments    []*Comment
+	Name        string
+	Description string
+	Salience    int
+	When        Expr
+	Then        []Stmt
+}
+
+func (e *BinaryExpr) Position() Pos   { return e.X.Position() }
+func (e *UnaryExpr) Position() Pos    { return e.OpPos }
+func (e *ParenExpr) Position() Pos    { return e.Lparen }
+func (e *Ident) Position() Pos        { return e.NamePos }
+func (e *SelectorExpr) Position() Pos { return e.X.Position() }
+func (e *CallExpr) Position() Pos     { return e.Fun.Position() }
+func (e *IndexExpr) Position() Pos    { return e.X.Position() }
+func (e *StringLit) Position() Pos    { return e.ValuePos }
+func (e *NumberLit) Position() Pos    { return e.ValuePos }
+func (e *BoolLit) Position() Pos      { return e.ValuePos }
+func (e *NilLit) Position() Pos       { return e.ValuePos }
+func (s *AssignStmt) Position() Pos   { return s.Lhs.Position() }
+func (s *ExprStmt) Position() Pos     { return s.X.Position() }
+func (r *RuleDecl) Position() Pos     { return r.RulePos }
+func (c *Comment) Position() Pos      { return c.Slash }
+
+func (*BinaryExpr) exprNode()   {}
+func (*UnaryExpr) exprNode()    {}
+func (*ParenExpr) exprNode()    {}
+func (*Ident) exprNode()        {}
+func (*SelectorExpr) exprNode() {}
+func (*CallExpr) exprNode()     {}
+func (*IndexExpr) exprNode()    {}
+func (*StringLit) exprNode()    {}
+func (*NumberLit) exprNode()    {}
+func (*BoolLit) exprNode()      {}
+func (*NilLit) exprNode()       {}
+func (*AssignStmt) stmtNode()   {}
+func (*ExprStmt) stmtNode()     {}
+
+// dottedName flattens an identifier/selector chain such as Customer.CartTotal.
+func dottedName(e Expr) (string, bool) {
+	switch v := e.(type) {
+	case *Ident:
+		return v.Name, true
+	case *SelectorExpr:
+		x, ok := dottedName(v.X)
+		if !ok {
+			return "", false
+		}
+		return x + "." + v.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// unparen strips any number of enclosing parentheses.
+func unparen(e Expr) Expr {
+	for {
+		p, ok := e.(*ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// formatExpr renders an expression back to GRL text, used to quote the
+// offending source in errors.
+func formatExpr(e Expr) string {
+	switch v := e.(type) {
+	case *BinaryExpr:
+		return formatExpr(v.X) + " " + v.Op + " " + formatExpr(v.Y)
+	case *UnaryExpr:
+		return v.Op + formatExpr(v.X)
+	case *ParenExpr:
+		return "(" + formatExpr(v.X) + ")"
+	case *Ident:
+		return v.Name
+	case *SelectorExpr:
+		return formatExpr(v.X) + "." + v.Sel.Name
+	case *CallExpr:
+		args := make([]string, 0, len(v.Args))
+		for _, arg := range v.Args {
+			args = append(args, formatExpr(arg))
+		}
+		return formatExpr(v.Fun) + "(" + strings.Join(args, ", ") + ")"
+	case *IndexExpr:
+		return formatExpr(v.X) + "[" + formatExpr(v.Index) + "]"
+	case *StringLit:
+		return v.Raw
+	case *NumberLit:
+		return v.Raw
+	case *BoolLit:
+		return strconv.FormatBool(v.Value)
+	case *NilLit:
+		return "nil"
+	}
+	return ""
+}
+
+// formatStmt renders a then statement back to GRL text.
+func formatStmt(s Stmt) string {
+	switch v := s.(type) {
+	case *AssignStmt:
+		return formatExpr(v.Lhs) + " " + v.Op + " " + formatExpr(v.Rhs)
+	case *ExprStmt:
+		return formatExpr(v.X)
+	}
+	return ""
+}
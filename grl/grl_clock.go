@@ -0,0 +1,122 @@
+package grl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// time zones of schedules do not depend on the zoneinfo of the host
+	_ "time/tzdata"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// ClockFactName is the name generated rules read the current time under.
+const ClockFactName = "Clock"
+
+// Clock tells rules with a valid_from or valid_until the current time. Add it
+// to every data context rules are executed with, see AddClock.
+type Clock struct {
+	now func() time.Time
+}
+
+// NewClock returns a Clock reading the current time from now, or from time.Now
+// when now is nil. Tests pin the time with a function returning a fixed time.
+func NewClock(now func() time.Time) *Clock {
+	if now == nil {
+		now = time.Now
+	}
+	return &Clock{now: now}
+}
+
+// AddClock registers clock in dc under ClockFactName.
+func AddClock(dc ast.IDataContext, clock *Clock) error {
+	return dc.Add(ClockFactName, clock)
+}
+
+// NotBefore reports whether the current time is at or after the RFC 3339
+// timestamp t.
+func (c *Clock) NotBefore(t string) bool {
+	return !c.now().Before(parseClockTime("NotBefore", t))
+}
+
+// Before reports whether the current time is before the RFC 3339 timestamp t.
+func (c *Clock) Before(t string) bool {
+	return c.now().Before(parseClockTime("Before", t))
+}
+
+// InSchedule reports whether the current time, in the IANA time zone zone,
+// falls on one of the weekdays and in one of the "HH:MM-HH:MM" time ranges
+// given in parts. Without weekdays every day matches, without time ranges the
+// whole day does.
+func (c *Clock) InSchedule(zone string, parts ...string) bool {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		panic(fmt.Sprintf("Clock.InSchedule: %v", err))
+	}
+	now := c.now().In(loc)
+	minute := now.Hour()*60 + now.Minute()
+	var hasDays, hasRanges, onDay, inRange bool
+	for _, part := range parts {
+		if day, ok := scheduleWeekday(part); ok {
+			hasDays = true
+			onDay = onDay || day == now.Weekday()
+			continue
+		}
+		start, end, ok := strings.Cut(part, "-")
+		if !ok {
+			panic(fmt.Sprintf("Clock.InSchedule: %q is neither a weekday nor a time range", part))
+		}
+		from, to, err := parseTimeRange(start, end)
+		if err != nil {
+			panic(fmt.Sprintf("Clock.InSchedule: %v", err))
+		}
+		hasRanges = true
+		inRange = inRange || minute >= from && minute < to
+	}
+	return (!hasDays || onDay) && (!hasRanges || inRange)
+}
+
+// parseClockTime parses the timestamp argument of fn. Grule reports the panic
+// of an invalid timestamp as an error of the rule that called fn.
+func parseClockTime(fn, t string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, t)
+	if err != nil {
+		panic(fmt.Sprintf("Clock.%s: %v", fn, err))
+	}
+	return parsed
+}
+
+// IsExpired reports whether the validity window of rule ended at or before now.
+func IsExpired(rule *dsl.EcommerceOfferRule, now time.Time) bool {
+	return rule.ValidUntil != nil && !now.Before(rule.ValidUntil.AsTime())
+}
+
+// validityTerms renders the validity window of rule as the Clock calls that
+// guard its when clause.
+func validityTerms(rule *dsl.EcommerceOfferRule) ([]string, error) {
+	terms := make([]string, 0, 2)
+	for _, bound := range []struct {
+		name string
+		fn   string
+		ts   *timestamppb.Timestamp
+	}{{"valid_from", "NotBefore", rule.ValidFrom}, {"valid_until", "Before", rule.ValidUntil}} {
+		if bound.ts == nil {
+			continue
+		}
+		if err := bound.ts.CheckValid(); err != nil {
+			return nil, fmt.Errorf("rule %s: %s: %v", rule.Name, bound.name, err)
+		}
+		terms = append(terms, fmt.Sprintf("%s.%s(%q)", ClockFactName, bound.fn, formatClockTime(bound.ts)))
+	}
+	if rule.ValidFrom != nil && rule.ValidUntil != nil && !rule.ValidFrom.AsTime().Before(rule.ValidUntil.AsTime()) {
+		return nil, fmt.Errorf("rule %s: valid_from %s is not before valid_until %s", rule.Name, formatClockTime(rule.ValidFrom), formatClockTime(rule.ValidUntil))
+	}
+	return terms, nil
+}
+
+func formatClockTime(ts *timestamppb.Timestamp) string {
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
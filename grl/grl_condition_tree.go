@@ -0,0 +1,276 @@
+package grl
+
+import (
+	"fmt"
+	"strings"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// ConditionTree returns the conditions of rule as a boolean tree. Rules using
+// the flat conditions and condition_join_operator fields are converted into a
+// group of condition groups, rules without conditions return nil.
+func ConditionTree(rule *dsl.EcommerceOfferRule) (*dsl.ConditionNode, error) {
+	if rule.ConditionTree != nil {
+		if len(rule.Conditions) > 0 {
+			return nil, fmt.Errorf("rule %s: conditions and condition_tree cannot both be set", rule.Name)
+		}
+		return rule.ConditionTree, nil
+	}
+	if len(rule.Conditions) == 0 {
+		return nil, nil
+	}
+	groups := make([]*dsl.ConditionNode, 0, len(rule.Conditions))
+	for _, cond := range rule.Conditions {
+		leaves := make([]*dsl.ConditionNode, 0, len(cond.Expressions))
+		for _, expr := range cond.Expressions {
+			leaves = append(leaves, expressionNode(expr))
+		}
+		groups = append(groups, groupNode(cond.ExpressionJoinOperator, leaves...))
+	}
+	return groupNode(rule.ConditionJoinOperator, groups...), nil
+}
+
+func expressionNode(expr *dsl.EcommerceOfferRule_Condition_Expression) *dsl.ConditionNode {
+	return &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: expr}}
+}
+
+func groupNode(op dsl.GRuleJoinOperator, children ...*dsl.ConditionNode) *dsl.ConditionNode {
+	return &dsl.ConditionNode{Node: &dsl.ConditionNode_Group_{Group: &dsl.ConditionNode_Group{
+		Operator: op,
+		Children: children,
+	}}}
+}
+
+func notNode(child *dsl.ConditionNode) *dsl.ConditionNode {
+	return &dsl.ConditionNode{Node: &dsl.ConditionNode_Not{Not: child}}
+}
+
+// whenRenderer renders a condition tree into a GRL when clause. Every
+// expression is written as `( expr )` and every nested group is wrapped in
+// another pair of parentheses, the layout the deserializer rebuilds groups from.
+type whenRenderer struct {
+	rule string
+	// paths overrides the location reported for expressions converted from
+	// the flat conditions, so errors keep pointing at conditions[i].expressions[j].
+	paths map[*dsl.EcommerceOfferRule_Condition_Expression]string
+}
+
+// whenClause renders the conditions of rule, behind the Clock calls checking
+// its validity window when it has one.
+func whenClause(rule *dsl.EcommerceOfferRule) (string, error) {
+	tree, err := ConditionTree(rule)
+	if err != nil {
+		return "", err
+	}
+	if tree == nil {
+		return "", fmt.Errorf("no conditions defined")
+	}
+	validity, err := validityTerms(rule)
+	if err != nil {
+		return "", err
+	}
+	terms := make([]string, 0, len(validity)+1)
+	for _, term := range validity {
+		terms = append(terms, fmt.Sprintf("( %s )", term))
+	}
+	r := &whenRenderer{rule: rule.Name}
+	path := "condition_tree"
+	if rule.ConditionTree == nil {
+		path = "conditions"
+		r.paths = make(map[*dsl.EcommerceOfferRule_Condition_Expression]string)
+		for i, cond := range rule.Conditions {
+			for j, expr := range cond.Expressions {
+				r.paths[expr] = fmt.Sprintf("conditions[%d].expressions[%d]", i, j)
+			}
+		}
+	}
+	// a group with a single child needs no parentheses of its own at the top
+	for {
+		group := tree.GetGroup()
+		if group == nil || len(group.Children) != 1 {
+			break
+		}
+		tree = group.Children[0]
+		path += ".group.children[0]"
+	}
+	var conditions string
+	if group := tree.GetGroup(); group != nil {
+		conditions, err = r.group(path+".group", group)
+		if len(terms) > 0 && len(group.Children) > 1 {
+			conditions = fmt.Sprintf("( %s )", conditions)
+		}
+	} else {
+		conditions, err = r.operand(path, tree)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append(terms, conditions), " && "), nil
+}
+
+// operand renders node as an operand of a group or negation.
+func (r *whenRenderer) operand(path string, node *dsl.ConditionNode) (string, error) {
+	switch n := node.GetNode().(type) {
+	case *dsl.ConditionNode_Expression:
+		return r.expression(path+".expression", n.Expression)
+	case *dsl.ConditionNode_Group_:
+		inner, err := r.group(path+".group", n.Group)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("( %s )", inner), nil
+	case *dsl.ConditionNode_Not:
+		inner, err := r.operand(path+".not", n.Not)
+		if err != nil {
+			return "", err
+		}
+		return "!" + inner, nil
+	case *dsl.ConditionNode_Schedule:
+		if err := checkSchedule(r.rule, path+".schedule", n.Schedule); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("( %s )", scheduleText(n.Schedule)), nil
+	default:
+		return "", fmt.Errorf("rule %s: %s: empty condition node", r.rule, path)
+	}
+}
+
+func (r *whenRenderer) group(path string, group *dsl.ConditionNode_Group) (string, error) {
+	if len(group.Children) == 0 {
+		return "", fmt.Errorf("rule %s: %s: empty condition group", r.rule, path)
+	}
+	if len(group.Children) > 1 && group.Operator != dsl.GRuleJoinOperator_AND && group.Operator != dsl.GRuleJoinOperator_OR {
+		return "", fmt.Errorf("rule %s: %s: unsupported join operator %s", r.rule, path, group.Operator)
+	}
+	operands := make([]string, 0, len(group.Children))
+	for i, child := range group.Children {
+		operand, err := r.operand(fmt.Sprintf("%s.children[%d]", path, i), child)
+		if err != nil {
+			return "", err
+		}
+		operands = append(operands, operand)
+	}
+	return strings.Join(operands, getEnumGrlOperator(group.Operator)), nil
+}
+
+// expression validates and renders a single expression as `( expr )`.
+func (r *whenRenderer) expression(path string, expr *dsl.EcommerceOfferRule_Condition_Expression) (string, error) {
+	if flat, ok := r.paths[expr]; ok {
+		path = flat
+	}
+	if err := checkAggregate(r.rule, path, expr); err != nil {
+		return "", err
+	}
+	if err := checkOperator(r.rule, path, expr); err != nil {
+		return "", err
+	}
+	if err := checkComputedValue(r.rule, path, expr); err != nil {
+		return "", err
+	}
+	if rangeOperators[expr.Operator] {
+		return r.rangeExpression(path, expr)
+	}
+	op := getEnumGrlOperator(expr.Operator)
+	field := getEnumGrlFieldName(expr.Input)
+	var val string
+	switch {
+	case !takesValue(expr.Operator):
+		if expr.Value != nil {
+			return "", fmt.Errorf("rule %s: %s: operator %s takes no value", r.rule, path, expr.Operator)
+		}
+	case setOperators[expr.Operator]:
+		if err := checkCandidates(r.rule, path, expr.Input, expr.Value); err != nil {
+			return "", err
+		}
+		var err error
+		if val, err = getCandidates(expr.Value, expr.Input); err != nil {
+			return "", err
+		}
+	default:
+		if err := checkValueTypeAs(r.rule, path, field, expressionFieldType(expr), expr.Value); err != nil {
+			return "", err
+		}
+		if err := checkPrecision(r.rule, path, expr.Input, expr.Value); err != nil {
+			return "", err
+		}
+		if err := checkPattern(r.rule, path, expr); err != nil {
+			return "", err
+		}
+		value := expr.Value
+		if expr.Operator == dsl.GRuleExpressionOperator_EQUALS_IGNORE_CASE {
+			value = &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: strings.ToLower(value.GetStringVal())}}
+		}
+		var err error
+		if val, err = getRuleValue(value, expr.Input); err != nil {
+			return "", err
+		}
+	}
+	field = comparedFieldText(expr)
+	if strings.Contains(op, ":field") {
+		if val == "" && takesValue(expr.Operator) {
+			return "", fmt.Errorf("%s used with empty list for field %s", expr.Operator, field)
+		}
+		opStr := strings.Replace(op, ":field", field, 1)
+		exprStr := strings.Replace(opStr, ":replace", val, 1)
+		return fmt.Sprintf("( %s )", exprStr), nil
+	}
+	return fmt.Sprintf("( %s%s%s )", field, op, val), nil
+}
+
+// rangeExpression renders a range operator as its two bound comparisons,
+// `( field >= lower && field <= upper )` for BETWEEN and
+// `( field < lower || field > upper )` for NOT_BETWEEN.
+func (r *whenRenderer) rangeExpression(path string, expr *dsl.EcommerceOfferRule_Condition_Expression) (string, error) {
+	if err := checkRange(r.rule, path, expr); err != nil {
+		return "", err
+	}
+	bounds := expr.Value.GetRangeVal()
+	lower, err := getRuleValue(bounds.Lower, expr.Input)
+	if err != nil {
+		return "", err
+	}
+	upper, err := getRuleValue(bounds.Upper, expr.Input)
+	if err != nil {
+		return "", err
+	}
+	lowerOp, upperOp := rangeBoundOperators(expr.Operator, bounds)
+	field := comparedFieldText(expr)
+	return fmt.Sprintf("( %s%s%s%s%s%s%s )",
+		field, getEnumGrlOperator(lowerOp), lower,
+		getEnumGrlOperator(expr.Operator),
+		field, getEnumGrlOperator(upperOp), upper), nil
+}
+
+// rangeBoundOperators returns the comparisons a range operator tests its lower
+// and upper bound with.
+func rangeBoundOperators(op dsl.GRuleExpressionOperator, bounds *dsl.Range) (lower, upper dsl.GRuleExpressionOperator) {
+	if op == dsl.GRuleExpressionOperator_NOT_BETWEEN {
+		lower, upper = dsl.GRuleExpressionOperator_LESS_THAN, dsl.GRuleExpressionOperator_GREATER_THAN
+		if bounds.LowerExclusive {
+			lower = dsl.GRuleExpressionOperator_LESS_THAN_EQUALS
+		}
+		if bounds.UpperExclusive {
+			upper = dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS
+		}
+		return lower, upper
+	}
+	lower, upper = dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS, dsl.GRuleExpressionOperator_LESS_THAN_EQUALS
+	if bounds.LowerExclusive {
+		lower = dsl.GRuleExpressionOperator_GREATER_THAN
+	}
+	if bounds.UpperExclusive {
+		upper = dsl.GRuleExpressionOperator_LESS_THAN
+	}
+	return lower, upper
+}
+
+// comparedFieldText renders what expr compares: its input field, wrapped in
+// the template of its aggregate if it has one.
+func comparedFieldText(expr *dsl.EcommerceOfferRule_Condition_Expression) string {
+	field := getEnumGrlFieldName(expr.Input)
+	if expr.Aggregate != dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED {
+		field = strings.Replace(getEnumGrlOperator(expr.Aggregate), ":field", field, 1)
+	}
+	return field
+}
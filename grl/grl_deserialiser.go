@@ -1,10 +1,15 @@
 package grl
 
 import (
-	"errors"
-	"regexp"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"grule-protobuf-dsl/dsl"
 )
@@ -27,6 +32,7 @@ var grlFieldToInputEnum = map[string]dsl.EcommerceOfferRule_Condition_InputField
 	"Customer.ReturnRatePercent":       dsl.EcommerceOfferRule_Condition_RETURN_RATE_PERCENT,
 	"Customer.HasRedeemedCouponBefore": dsl.EcommerceOfferRule_Condition_HAS_COUPON_REDEEMED_BEFORE,
 	"Customer.SignupDaysAgo":           dsl.EcommerceOfferRule_Condition_SIGNUP_DAYS_AGO,
+	"Customer.LifetimeLoyaltyPoints":   dsl.EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS,
 }
 
 var outputFieldToGRLName = map[dsl.EcommerceOfferRule_Action_OutputField]string{
@@ -37,153 +43,1392 @@ var outputFieldToGRLName = map[dsl.EcommerceOfferRule_Action_OutputField]string{
 	dsl.EcommerceOfferRule_Action_ASSIGN_COUPON_CODE:     "Offer.AssignCoupon",
 	dsl.EcommerceOfferRule_Action_PROMO_MESSAGE:          "Offer.PromoMessage",
 	dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS:     "Offer.AddLoyaltyPoints",
+	dsl.EcommerceOfferRule_Action_PROMO_TAGS:             "Offer.PromoTags",
+}
+
+// grlNameToOutputField is the reverse of outputFieldToGRLName.
+var grlNameToOutputField = func() map[string]dsl.EcommerceOfferRule_Action_OutputField {
+	m := make(map[string]dsl.EcommerceOfferRule_Action_OutputField, len(outputFieldToGRLName))
+	for output, grlName := range outputFieldToGRLName {
+		m[grlName] = output
+	}
+	return m
+}()
+
+// comparisonOperators maps a GRL comparison token such as ">=" to its expression
+// operator, using the grl_operator annotations of GRuleExpressionOperator.
+var comparisonOperators = func() map[string]dsl.GRuleExpressionOperator {
+	m := make(map[string]dsl.GRuleExpressionOperator)
+	values := dsl.GRuleExpressionOperator(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		op := dsl.GRuleExpressionOperator(values.Get(i).Number())
+		token := strings.TrimSpace(getEnumGrlOperator(op))
+		if op == dsl.GRuleExpressionOperator_EXPRESSION_OPERATOR_UNSPECIFIED || strings.Contains(token, ":") || rangeOperators[op] {
+			continue
+		}
+		m[token] = op
+	}
+	return m
+}()
+
+// Placeholders of grl_operator templates are replaced by these identifiers so
+// that a template can be parsed like any other GRL expression.
+const (
+	templateFieldIdent   = "__field"
+	templateReplaceIdent = "__replace"
+)
+
+// operatorTemplate is a function-style grl_operator annotation, for example
+// "Customer.HasCategory(:field, :replace)", parsed into an expression pattern.
+type operatorTemplate struct {
+	operator dsl.GRuleExpressionOperator
+	pattern  Expr
+}
+
+// operatorTemplates holds the parsed templates of all function-style operators.
+var operatorTemplates = func() []operatorTemplate {
+	var templates []operatorTemplate
+	values := dsl.GRuleExpressionOperator(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		op := dsl.GRuleExpressionOperator(values.Get(i).Number())
+		if template := getEnumGrlOperator(op); strings.Contains(template, ":field") {
+			templates = append(templates, operatorTemplate{operator: op, pattern: parseTemplate(op, template)})
+		}
+	}
+	return templates
+}()
+
+// aggregateTemplate is the parsed grl_operator annotation of an aggregate,
+// for example "Helper.Count(:field)".
+type aggregateTemplate struct {
+	aggregate dsl.GRuleAggregate
+	pattern   Expr
+}
+
+// aggregateTemplates holds the parsed templates of all aggregates.
+var aggregateTemplates = func() []aggregateTemplate {
+	var templates []aggregateTemplate
+	values := dsl.GRuleAggregate(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		agg := dsl.GRuleAggregate(values.Get(i).Number())
+		if template := getEnumGrlOperator(agg); strings.Contains(template, ":field") {
+			templates = append(templates, aggregateTemplate{aggregate: agg, pattern: parseTemplate(agg, template)})
+		}
+	}
+	return templates
+}()
+
+// arithmeticOperators maps the GRL token of a binary arithmetic operator such
+// as "+" to its ArithmeticOperator, arithmeticTemplates holds the parsed call
+// templates of the others.
+var arithmeticOperators, arithmeticTemplates = func() (map[string]dsl.ArithmeticOperator, map[dsl.ArithmeticOperator]Expr) {
+	tokens := make(map[string]dsl.ArithmeticOperator)
+	templates := make(map[dsl.ArithmeticOperator]Expr)
+	values := dsl.ArithmeticOperator(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		op := dsl.ArithmeticOperator(values.Get(i).Number())
+		template := getEnumGrlOperator(op)
+		switch {
+		case template == "":
+		case strings.Contains(template, ":replace"):
+			templates[op] = parseTemplate(op, template)
+		default:
+			tokens[strings.TrimSpace(template)] = op
+		}
+	}
+	return tokens, templates
+}()
+
+// actionOperators maps the GRL assignment operators onto action operators,
+// actionTemplates holds the parsed templates of the others.
+var actionOperators, actionTemplates = func() (map[string]dsl.EcommerceOfferRule_Action_Operator, map[dsl.EcommerceOfferRule_Action_Operator]Expr) {
+	tokens := make(map[string]dsl.EcommerceOfferRule_Action_Operator)
+	templates := make(map[dsl.EcommerceOfferRule_Action_Operator]Expr)
+	values := dsl.EcommerceOfferRule_Action_Operator(0).Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		op := dsl.EcommerceOfferRule_Action_Operator(values.Get(i).Number())
+		if template := getEnumGrlOperator(op); strings.Contains(template, ":field") {
+			templates[op] = parseTemplate(op, template)
+		} else {
+			tokens[strings.TrimSpace(template)] = op
+		}
+	}
+	return tokens, templates
+}()
+
+// parseTemplate parses a grl_operator template into an expression pattern.
+func parseTemplate(enum fmt.Stringer, template string) Expr {
+	src := strings.NewReplacer(":field", templateFieldIdent, ":replace", templateReplaceIdent).Replace(template)
+	p := newParser(src)
+	pattern, err := p.parseExpr()
+	if err != nil {
+		panic(fmt.Sprintf("invalid grl_operator template for %s: %v", enum, p.failure(err)))
+	}
+	return pattern
+}
+
+// templateBinding collects what the placeholders of a template matched.
+type templateBinding struct {
+	field    string
+	replaced []Expr
+}
+
+// matchTemplate reports whether e has the shape of pattern. The :replace
+// placeholder, when it is the last call argument, absorbs all remaining arguments.
+func matchTemplate(pattern, e Expr, b *templateBinding) bool {
+	switch pt := pattern.(type) {
+	case *Ident:
+		switch pt.Name {
+		case templateFieldIdent:
+			name, ok := dottedName(e)
+			b.field = name
+			return ok
+		case templateReplaceIdent:
+			b.replaced = append(b.replaced, e)
+			return true
+		}
+		id, ok := e.(*Ident)
+		return ok && id.Name == pt.Name
+	case *SelectorExpr:
+		sel, ok := e.(*SelectorExpr)
+		return ok && sel.Sel.Name == pt.Sel.Name && matchTemplate(pt.X, sel.X, b)
+	case *CallExpr:
+		call, ok := e.(*CallExpr)
+		if !ok || !matchTemplate(pt.Fun, call.Fun, b) {
+			return false
+		}
+		args := call.Args
+		for i, arg := range pt.Args {
+			if id, ok := arg.(*Ident); ok && id.Name == templateReplaceIdent && i == len(pt.Args)-1 {
+				b.replaced = append(b.replaced, args...)
+				return len(args) > 0
+			}
+			if len(args) == 0 || !matchTemplate(arg, args[0], b) {
+				return false
+			}
+			args = args[1:]
+		}
+		return len(args) == 0
+	case *BinaryExpr:
+		bin, ok := e.(*BinaryExpr)
+		return ok && bin.Op == pt.Op && matchTemplate(pt.X, bin.X, b) && matchTemplate(pt.Y, bin.Y, b)
+	case *UnaryExpr:
+		un, ok := e.(*UnaryExpr)
+		return ok && un.Op == pt.Op && matchTemplate(pt.X, un.X, b)
+	case *ParenExpr:
+		return matchTemplate(pt.X, unparen(e), b)
+	case *StringLit:
+		lit, ok := e.(*StringLit)
+		return ok && lit.Value == pt.Value
+	case *NumberLit:
+		lit, ok := e.(*NumberLit)
+		return ok && lit.Raw == pt.Raw
+	case *BoolLit:
+		lit, ok := e.(*BoolLit)
+		return ok && lit.Value == pt.Value
+	}
+	return false
+}
+
+// isOperatorTemplate reports whether e matches one of the operator templates,
+// such as the negated call of NOT_IN.
+func isOperatorTemplate(e Expr) bool {
+	for _, template := range operatorTemplates {
+		if matchTemplate(template.pattern, e, &templateBinding{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnostic describes a construct of a GRL rule that has no EcommerceOfferRule
+// equivalent and was therefore left out of the decompiled rule.
+type Diagnostic struct {
+	Rule   string
+	Pos    Pos
+	Text   string
+	Reason string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("rule %s line %s: %s: %s", d.Rule, d.Pos, d.Reason, d.Text)
+}
+
+// Diagnostics is the list of constructs dropped while decompiling. In strict
+// mode it is returned as the error.
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	msgs := make([]string, 0, len(d))
+	for _, diag := range d {
+		msgs = append(msgs, diag.String())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// DecompileOptions controls how GRL constructs without a DSL equivalent are handled.
+type DecompileOptions struct {
+	// Strict fails the decompile when anything would be dropped. Otherwise the
+	// dropped constructs are returned as warnings next to the rule.
+	Strict bool
 }
 
 // ParseGRLToRuleEntity parses a GRL string into an EcommerceOfferRule proto
 func ParseGRLToRuleEntity(grl string) (*dsl.EcommerceOfferRule, error) {
-	grl = strings.TrimSpace(grl)
-	if !strings.HasPrefix(grl, "rule") {
-		return nil, errors.New("invalid GRL: must start with 'rule'")
+	rule, _, err := DecompileGRL(grl, DecompileOptions{})
+	return rule, err
+}
+
+// DecompileGRL parses a single GRL rule into an EcommerceOfferRule proto and
+// reports every expression or statement it could not map. Dropping a condition
+// makes a rule broader than its source, so imports of discount rules should use
+// strict mode, which returns the Diagnostics as the error instead.
+func DecompileGRL(grl string, opts DecompileOptions) (*dsl.EcommerceOfferRule, Diagnostics, error) {
+	decl, err := parseRule(grl)
+	if err != nil {
+		return nil, nil, err
 	}
+	return decompileRule(decl, opts)
+}
 
-	nameRegex := regexp.MustCompile(`(?m)^rule\s+(\S+)\s+\"(.*?)\"\s+salience\s+(\d+)\s+\{`)
-	whenRegex := regexp.MustCompile(`(?s)when\s+(.*?)then`)
-	thenRegex := regexp.MustCompile(`(?s)then\s+(.*?)\}`)
+// RuleError reports why one rule of a GRL document could not be decompiled.
+type RuleError struct {
+	// Rule is the rule name, empty when the parser could not read it.
+	Rule string
+	// Line is the line the rule entry starts on.
+	Line int
+	Err  error
+}
 
-	nameMatch := nameRegex.FindStringSubmatch(grl)
-	if len(nameMatch) != 4 {
-		return nil, errors.New("could not extract rule name/description/salience")
+func (e *RuleError) Error() string {
+	name := e.Rule
+	if name == "" {
+		name = "<unnamed>"
 	}
+	return fmt.Sprintf("rule %s at line %d: %v", name, e.Line, e.Err)
+}
 
-	name := nameMatch[1]
-	description := nameMatch[2]
-	salience, _ := strconv.Atoi(nameMatch[3])
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// DocumentError lists the rules of a GRL document that failed to decompile.
+type DocumentError struct {
+	Errors []*RuleError
+}
 
-	whenMatch := whenRegex.FindStringSubmatch(grl)
-	thenMatch := thenRegex.FindStringSubmatch(grl)
-	if len(whenMatch) < 2 || len(thenMatch) < 2 {
-		return nil, errors.New("could not extract when/then clauses")
+func (e *DocumentError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
 	}
+	return fmt.Sprintf("%d rule(s) failed to decompile:\n%s", len(e.Errors), strings.Join(msgs, "\n"))
+}
 
-	whenClause := strings.TrimSpace(whenMatch[1])
-	thenClause := strings.TrimSpace(thenMatch[1])
+// ParseGRLDocument parses a GRL document holding any number of rules, such as
+// the output of ToMultipleGRLs, into EcommerceOfferRule protos. Rules that fail
+// do not stop the others: the decompiled rules are returned together with a
+// *DocumentError describing each failure.
+func ParseGRLDocument(r io.Reader) ([]*dsl.EcommerceOfferRule, error) {
+	rules, _, err := DecompileGRLDocument(r, DecompileOptions{})
+	return rules, err
+}
 
+// DecompileGRLDocument is ParseGRLDocument with diagnostics. In strict mode a
+// rule with diagnostics fails with a *RuleError wrapping its Diagnostics.
+func DecompileGRLDocument(r io.Reader, opts DecompileOptions) ([]*dsl.EcommerceOfferRule, Diagnostics, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := parseDocument(string(src))
+
+	rules := make([]*dsl.EcommerceOfferRule, 0, len(entries))
+	var docErr DocumentError
+	var warnings Diagnostics
+	seen := make(map[string]int)
+	for _, entry := range entries {
+		if entry.err != nil {
+			docErr.Errors = append(docErr.Errors, &RuleError{Rule: entry.name, Line: entry.pos.Line, Err: entry.err})
+			continue
+		}
+		if line, ok := seen[entry.name]; ok {
+			docErr.Errors = append(docErr.Errors, &RuleError{Rule: entry.name, Line: entry.pos.Line,
+				Err: fmt.Errorf("duplicate rule name, first declared at line %d", line)})
+			continue
+		}
+		seen[entry.name] = entry.pos.Line
+		rule, diags, err := decompileRule(entry.decl, opts)
+		if err != nil {
+			docErr.Errors = append(docErr.Errors, &RuleError{Rule: entry.name, Line: entry.pos.Line, Err: err})
+			continue
+		}
+		warnings = append(warnings, diags...)
+		rules = append(rules, rule)
+	}
+	if len(docErr.Errors) > 0 {
+		return rules, warnings, &docErr
+	}
+	return rules, warnings, nil
+}
+
+// decompiler maps one parsed rule entry onto an EcommerceOfferRule and keeps
+// track of what it had to drop.
+type decompiler struct {
+	rule  string
+	diags Diagnostics
+}
+
+func (d *decompiler) report(n Node, text, reason string) {
+	d.diags = append(d.diags, Diagnostic{Rule: d.rule, Pos: n.Position(), Text: text, Reason: reason})
+}
+
+// decompileRule maps a parsed rule entry onto an EcommerceOfferRule.
+func decompileRule(decl *RuleDecl, opts DecompileOptions) (*dsl.EcommerceOfferRule, Diagnostics, error) {
+	d := &decompiler{rule: decl.Name}
+	rule, err := d.ruleDecl(decl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.Strict && len(d.diags) > 0 {
+		return nil, nil, d.diags
+	}
+	return rule, d.diags, nil
+}
+
+func (d *decompiler) ruleDecl(decl *RuleDecl) (*dsl.EcommerceOfferRule, error) {
+	if decl.Salience < 0 {
+		return nil, fmt.Errorf("line %s: negative salience %d is not supported", decl.RulePos, decl.Salience)
+	}
 	rule := &dsl.EcommerceOfferRule{
-		Name:        name,
-		Description: description,
-		Salience:    uint32(salience),
-	}
-
-	// Parse WHEN clause
-	condition := &dsl.EcommerceOfferRule_Condition{
-		ExpressionJoinOperator: dsl.GRuleJoinOperator_AND, // Default
-	}
-	expressions := strings.Split(whenClause, "&&")
-	for _, expr := range expressions {
-		expr = strings.Trim(expr, " ()")
-		for grlField, inputEnum := range grlFieldToInputEnum {
-			if strings.Contains(expr, grlField) {
-				operator := detectOperator(expr)
-				val := extractValue(expr, operator)
-				condition.Expressions = append(condition.Expressions, &dsl.EcommerceOfferRule_Condition_Expression{
-					Input:    inputEnum,
-					Operator: operator,
-					Value:    parseValue(val),
-				})
+		Name:        decl.Name,
+		Description: decl.Description,
+		Salience:    uint32(decl.Salience),
+		Metadata:    d.metadata(decl.Comments),
+	}
+
+	// Map WHEN clause
+	when, err := d.validity(rule, decl.When)
+	if err != nil {
+		return nil, err
+	}
+	conditions, conditionJoin, tree, err := d.conditions(when)
+	if err != nil {
+		return nil, err
+	}
+	rule.Conditions = conditions
+	rule.ConditionJoinOperator = conditionJoin
+	rule.ConditionTree = tree
+
+	// Map THEN clause. A rule that neither retracts itself nor completes the
+	// engine re-fires.
+	rule.TerminationMode = dsl.GRuleTerminationMode_REFIRE
+	var terminator Stmt
+	var changed []string
+	for _, stmt := range decl.Then {
+		if mode, ok := d.terminationMode(stmt); ok {
+			if terminator != nil {
+				d.report(stmt, formatStmt(stmt), "conflicts with "+formatStmt(terminator))
+				continue
+			}
+			terminator = stmt
+			rule.TerminationMode = mode
+			continue
+		}
+		if field, ok := changedOutput(stmt); ok {
+			changed = append(changed, field)
+			continue
+		}
+		action, err := d.action(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil {
+			rule.Actions = append(rule.Actions, action)
+		}
+	}
+	if terminator == nil && !slices.Equal(changed, assignedOutputs(rule.Actions)) {
+		// the serializer writes a re-firing rule with one Changed call per
+		// assigned field, which this then block does not match
+		d.report(decl, "rule "+decl.Name, "neither retracts the rule nor completes the engine")
+	}
+
+	return rule, nil
+}
+
+// assignedOutputs lists the GRL names of the outputs actions assign, in the
+// order the serializer calls Changed on them.
+func assignedOutputs(actions []*dsl.EcommerceOfferRule_Action) []string {
+	var fields []string
+	for _, action := range actions {
+		field := outputFieldToGRLName[action.Output]
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// action maps an `Offer.<field> <op> <value>` statement onto an action, where
+// op is an assignment operator such as += or `=` followed by an action
+// operator template applied to the same field.
+func (d *decompiler) action(stmt Stmt) (*dsl.EcommerceOfferRule_Action, error) {
+	assign, ok := stmt.(*AssignStmt)
+	if !ok {
+		d.report(stmt, formatStmt(stmt), "unsupported statement")
+		return nil, nil
+	}
+	operator, ok := actionOperators[assign.Op]
+	if !ok {
+		d.report(stmt, formatStmt(stmt), "unsupported assignment operator "+assign.Op)
+		return nil, nil
+	}
+	name, _ := dottedName(assign.Lhs)
+	output, ok := grlNameToOutputField[name]
+	if !ok {
+		d.report(stmt, formatStmt(stmt), "unknown output field "+name)
+		return nil, nil
+	}
+	fieldType := getEnumGrlFieldType(output)
+	var args []Expr
+	if operator == dsl.EcommerceOfferRule_Action_SET {
+		for op, pattern := range actionTemplates {
+			var b templateBinding
+			if matchTemplate(pattern, unparen(assign.Rhs), &b) && b.field == name {
+				operator, args = op, b.replaced
 				break
 			}
 		}
 	}
-	if len(condition.Expressions) > 0 {
-		rule.Conditions = append(rule.Conditions, condition)
+	if !IsActionOperatorApplicable(operator, fieldType) {
+		d.report(stmt, formatStmt(stmt), fmt.Sprintf("action operator %s cannot be applied to %s", operator, name))
+		return nil, nil
+	}
+	var val *dsl.RuleValue
+	var err error
+	switch {
+	case args == nil:
+		val, err = operandValue(name, fieldType, assign.Rhs)
+	case fieldType == dsl.FieldType_STRING_LIST:
+		val, err = listValue(name, args)
+	case len(args) == 1:
+		val, err = operandValue(name, fieldType, args[0])
+	default:
+		err = &ValueCoercionError{Pos: args[1].Position(), Field: name, Expected: fieldType, Literal: formatArgs(args)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dsl.EcommerceOfferRule_Action{
+		Output:   output,
+		Value:    val,
+		Operator: operator,
+	}, nil
+}
+
+// listValue converts the arguments appended to a list output: a list input
+// field, or string literals that become a string list.
+func listValue(field string, args []Expr) (*dsl.RuleValue, error) {
+	if _, ok := dottedName(unparen(args[0])); ok && len(args) == 1 {
+		return operandValue(field, dsl.FieldType_STRING_LIST, args[0])
+	}
+	return stringList(field, dsl.FieldType_STRING_LIST, args)
+}
+
+// stringList converts string literal arguments into a string list.
+func stringList(field string, fieldType dsl.FieldType, args []Expr) (*dsl.RuleValue, error) {
+	list := &dsl.StringList{Values: make([]string, 0, len(args))}
+	for _, arg := range args {
+		lit, ok := unparen(arg).(*StringLit)
+		if !ok {
+			return nil, &ValueCoercionError{Pos: arg.Position(), Field: field, Expected: fieldType, Literal: formatExpr(arg)}
+		}
+		list.Values = append(list.Values, lit.Value)
+	}
+	return &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: list}}, nil
+}
+
+// terminationMode recognises the statements the serializer ends a rule with:
+// `Retract("<rule>")` and `Complete()`.
+func (d *decompiler) terminationMode(stmt Stmt) (dsl.GRuleTerminationMode, bool) {
+	name, args, ok := callStmt(stmt)
+	switch {
+	case !ok:
+		return 0, false
+	case name == "Complete" && len(args) == 0:
+		return dsl.GRuleTerminationMode_COMPLETE_ENGINE, true
+	case name == "Retract" && len(args) == 1:
+		arg, ok := args[0].(*StringLit)
+		if ok && arg.Value == d.rule {
+			return dsl.GRuleTerminationMode_RETRACT_SELF, true
+		}
+	}
+	return 0, false
+}
+
+// changedOutput returns the output field of stmt when it is
+// `Changed("Offer.<field>")`, which the serializer emits for re-firing rules.
+func changedOutput(stmt Stmt) (string, bool) {
+	name, args, ok := callStmt(stmt)
+	if !ok || name != "Changed" || len(args) != 1 {
+		return "", false
+	}
+	arg, ok := args[0].(*StringLit)
+	if !ok {
+		return "", false
+	}
+	_, ok = grlNameToOutputField[arg.Value]
+	return arg.Value, ok
+}
+
+// callStmt unpacks a statement made of a single function call.
+func callStmt(stmt Stmt) (string, []Expr, bool) {
+	es, ok := stmt.(*ExprStmt)
+	if !ok {
+		return "", nil, false
+	}
+	call, ok := es.X.(*CallExpr)
+	if !ok {
+		return "", nil, false
 	}
+	name, _ := dottedName(call.Fun)
+	return name, call.Args, true
+}
+
+// logicalJoinOperators maps the GRL logical tokens onto join operators.
+var logicalJoinOperators = map[string]dsl.GRuleJoinOperator{
+	"&&": dsl.GRuleJoinOperator_AND,
+	"||": dsl.GRuleJoinOperator_OR,
+}
 
-	// Parse THEN clause
-	thenLines := strings.Split(thenClause, ";")
-	for _, line := range thenLines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+// validity moves the Clock calls of the top level AND chain of a when clause
+// into the validity window of rule and returns the rest of the clause. A clause
+// made of Clock calls only is rejected.
+func (d *decompiler) validity(rule *dsl.EcommerceOfferRule, when Expr) (Expr, error) {
+	terms, join := splitChain(stripGroupParens(when))
+	if join != dsl.GRuleJoinOperator_AND {
+		return when, nil
+	}
+	rest := make([]Expr, 0, len(terms))
+	for _, term := range terms {
+		call, ok := unparen(term).(*CallExpr)
+		var name string
+		var bound func(*dsl.EcommerceOfferRule) **timestamppb.Timestamp
+		if ok {
+			name, _ = dottedName(call.Fun)
+			bound, ok = validityBounds[name]
+		}
+		if !ok {
+			rest = append(rest, term)
 			continue
 		}
-		parts := strings.Split(line, "=")
-		if len(parts) != 2 {
+		arg, ok := singleStringArg(call)
+		if !ok {
+			return nil, fmt.Errorf("line %s: %s expects an RFC 3339 timestamp", term.Position(), name)
+		}
+		t, err := time.Parse(time.RFC3339Nano, arg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("line %s: %s: %v", arg.Position(), name, err)
+		}
+		ts := bound(rule)
+		if *ts != nil {
+			d.report(term, formatExpr(term), "repeats the validity bound")
 			continue
 		}
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
+		*ts = timestamppb.New(t)
+	}
+	if len(rest) == len(terms) {
+		return when, nil
+	}
+	if len(rest) == 0 {
+		// a rule needs conditions, the window only guards them
+		return nil, fmt.Errorf("line %s: rule has no conditions besides its validity window", when.Position())
+	}
+	chain := rest[0]
+	for _, term := range rest[1:] {
+		chain = &BinaryExpr{OpPos: term.Position(), Op: "&&", X: chain, Y: term}
+	}
+	return chain, nil
+}
 
-		for output, grlName := range outputFieldToGRLName {
-			if grlName == left {
-				rule.Actions = append(rule.Actions, &dsl.EcommerceOfferRule_Action{
-					Output: output,
-					Value:  parseValue(right),
-				})
-				break
+// validityBounds maps the Clock calls the serializer guards a when clause with
+// onto the validity bound of the rule they check.
+var validityBounds = map[string]func(*dsl.EcommerceOfferRule) **timestamppb.Timestamp{
+	ClockFactName + ".NotBefore": func(r *dsl.EcommerceOfferRule) **timestamppb.Timestamp { return &r.ValidFrom },
+	ClockFactName + ".Before":    func(r *dsl.EcommerceOfferRule) **timestamppb.Timestamp { return &r.ValidUntil },
+}
+
+func singleStringArg(call *CallExpr) (*StringLit, bool) {
+	if len(call.Args) != 1 {
+		return nil, false
+	}
+	lit, ok := unparen(call.Args[0]).(*StringLit)
+	return lit, ok
+}
+
+// conditions rebuilds the conditions of a when clause. The serializer renders
+// every expression as `( expr )` and wraps every nested group in another pair of
+// parentheses, so `( ( a ) || ( b ) ) && ( ( c ) )` decompiles into two
+// condition groups joined by AND. Clauses that fit the flat conditions are
+// returned as such, anything deeper or negated is returned as a condition tree.
+func (d *decompiler) conditions(when Expr) ([]*dsl.EcommerceOfferRule_Condition, dsl.GRuleJoinOperator, *dsl.ConditionNode, error) {
+	// parentheses around the whole clause carry no grouping information
+	terms, join := splitChain(stripGroupParens(when))
+	root := &dsl.ConditionNode_Group{Operator: join}
+	for _, term := range terms {
+		child, err := d.conditionNode(term)
+		if err != nil {
+			return nil, dsl.GRuleJoinOperator_AND, nil, err
+		}
+		if child != nil {
+			root.Children = append(root.Children, child)
+		}
+	}
+	foldMembership(root)
+	foldRanges(root)
+	if conditions, conditionJoin, ok := flattenTree(root); ok {
+		return conditions, conditionJoin, nil, nil
+	}
+	return nil, dsl.GRuleJoinOperator_JOIN_OPERATOR_UNSPECIFIED, simplifyTree(&dsl.ConditionNode{Node: &dsl.ConditionNode_Group_{Group: root}}), nil
+}
+
+// conditionNode rebuilds the condition tree of one operand. Expressions that
+// are reported and dropped leave no node, in which case nil is returned.
+func (d *decompiler) conditionNode(e Expr) (*dsl.ConditionNode, error) {
+	if not, ok := unparen(e).(*UnaryExpr); ok && not.Op == "!" && !isOperatorTemplate(unparen(e)) {
+		child, err := d.conditionNode(not.X)
+		if err != nil || child == nil {
+			return nil, err
+		}
+		return notNode(child), nil
+	}
+	if call, ok := unparen(e).(*CallExpr); ok {
+		if name, _ := dottedName(call.Fun); name == scheduleFunc {
+			schedule, err := scheduleValue(call)
+			if err != nil {
+				return nil, err
 			}
+			return &dsl.ConditionNode{Node: &dsl.ConditionNode_Schedule{Schedule: schedule}}, nil
+		}
+	}
+	if !isConditionGroup(e) {
+		expr, err := d.expression(e)
+		if err != nil || expr == nil {
+			return nil, err
 		}
+		return expressionNode(expr), nil
 	}
+	terms, join := splitChain(stripGroupParens(e))
+	children := make([]*dsl.ConditionNode, 0, len(terms))
+	for _, term := range terms {
+		child, err := d.conditionNode(term)
+		if err != nil {
+			return nil, err
+		}
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	if len(children) == 0 {
+		return nil, nil
+	}
+	node := groupNode(join, children...)
+	foldMembership(node.GetGroup())
+	foldRanges(node.GetGroup())
+	// a group folded into a single expression is that expression
+	if group := node.GetGroup(); len(children) > 1 && len(group.Children) == 1 {
+		return group.Children[0], nil
+	}
+	return node, nil
+}
 
-	return rule, nil
+// metadata reads the `// @key: value` comments above a rule back into its
+// RuleMetadata, nil when there are none. Other comments, including those with
+// an unknown key, are ignored.
+func (d *decompiler) metadata(comments []*Comment) *dsl.RuleMetadata {
+	m := &dsl.RuleMetadata{}
+	var notes []string
+	found := false
+	for _, comment := range comments {
+		key, value, ok := metadataLine(comment.Text)
+		if !ok {
+			continue
+		}
+		var single *string
+		switch key {
+		case metadataOwnerTeam:
+			single = &m.OwnerTeam
+		case metadataTicket:
+			single = &m.Ticket
+		case metadataCreatedBy:
+			single = &m.CreatedBy
+		case metadataLabels:
+			m.Labels = append(m.Labels, value)
+		case metadataNotes:
+			notes = append(notes, value)
+		default:
+			// not a RuleMetadata field, an ordinary comment
+			continue
+		}
+		found = true
+		if single == nil {
+			continue
+		}
+		if *single != "" {
+			d.report(comment, "//"+comment.Text, "repeats the metadata key")
+			continue
+		}
+		*single = value
+	}
+	if !found {
+		return nil
+	}
+	m.Notes = strings.Join(notes, "\n")
+	return m
 }
 
-func detectOperator(expr string) dsl.GRuleExpressionOperator {
-	switch {
-	case strings.Contains(expr, " >= "):
-		return dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS
-	case strings.Contains(expr, " <= "):
-		return dsl.GRuleExpressionOperator_LESS_THAN_EQUALS
-	case strings.Contains(expr, " > "):
-		return dsl.GRuleExpressionOperator_GREATER_THAN
-	case strings.Contains(expr, " < "):
-		return dsl.GRuleExpressionOperator_LESS_THAN
-	case strings.Contains(expr, " == "):
-		return dsl.GRuleExpressionOperator_EQUALS
-	case strings.Contains(expr, " != "):
-		return dsl.GRuleExpressionOperator_NOT_EQUALS
-	case strings.Contains(expr, " Customer.HasCategory( "):
-		return dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION
-	default:
-		return dsl.GRuleExpressionOperator_EXPRESSION_OPERATOR_UNSPECIFIED
+// scheduleValue converts a call of scheduleFunc: the time zone followed by
+// weekday names and "HH:MM-HH:MM" time ranges.
+func scheduleValue(call *CallExpr) (*dsl.Schedule, error) {
+	parts := make([]string, 0, len(call.Args))
+	for _, arg := range call.Args {
+		lit, ok := unparen(arg).(*StringLit)
+		if !ok {
+			return nil, fmt.Errorf("line %s: %s expects string arguments, got %s", arg.Position(), scheduleFunc, formatExpr(arg))
+		}
+		parts = append(parts, lit.Value)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("line %s: %s expects a time zone", call.Lparen, scheduleFunc)
+	}
+	schedule := &dsl.Schedule{TimeZone: parts[0]}
+	for i, part := range parts[1:] {
+		if _, ok := scheduleWeekday(part); ok {
+			schedule.Days = append(schedule.Days, dsl.Weekday(dsl.Weekday_value[part]))
+			continue
+		}
+		start, end, _ := strings.Cut(part, "-")
+		if _, _, err := parseTimeRange(start, end); err != nil {
+			return nil, fmt.Errorf("line %s: %s: %q is neither a weekday nor a valid time range", call.Args[i+1].Position(), scheduleFunc, part)
+		}
+		schedule.TimeRanges = append(schedule.TimeRanges, &dsl.Schedule_TimeRange{Start: start, End: end})
+	}
+	return schedule, nil
+}
+
+// flattenTree converts the root group of a condition tree into flat conditions
+// when it only holds expressions, or expressions and groups of expressions.
+func flattenTree(root *dsl.ConditionNode_Group) ([]*dsl.EcommerceOfferRule_Condition, dsl.GRuleJoinOperator, bool) {
+	if len(root.Children) == 0 {
+		return nil, dsl.GRuleJoinOperator_AND, true
+	}
+	if exprs, ok := groupExpressions(root); ok {
+		return []*dsl.EcommerceOfferRule_Condition{{Expressions: exprs, ExpressionJoinOperator: root.Operator}}, dsl.GRuleJoinOperator_AND, true
+	}
+	conditions := make([]*dsl.EcommerceOfferRule_Condition, 0, len(root.Children))
+	for _, child := range root.Children {
+		switch n := child.GetNode().(type) {
+		case *dsl.ConditionNode_Expression:
+			conditions = append(conditions, &dsl.EcommerceOfferRule_Condition{
+				Expressions:            []*dsl.EcommerceOfferRule_Condition_Expression{n.Expression},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			})
+		case *dsl.ConditionNode_Group_:
+			exprs, ok := groupExpressions(n.Group)
+			if !ok {
+				return nil, dsl.GRuleJoinOperator_AND, false
+			}
+			conditions = append(conditions, &dsl.EcommerceOfferRule_Condition{Expressions: exprs, ExpressionJoinOperator: n.Group.Operator})
+		default:
+			return nil, dsl.GRuleJoinOperator_AND, false
+		}
+	}
+	return conditions, root.Operator, true
+}
+
+// groupExpressions returns the expressions of a group made of expressions only.
+func groupExpressions(group *dsl.ConditionNode_Group) ([]*dsl.EcommerceOfferRule_Condition_Expression, bool) {
+	exprs := make([]*dsl.EcommerceOfferRule_Condition_Expression, 0, len(group.Children))
+	for _, child := range group.Children {
+		expr := child.GetExpression()
+		if expr == nil {
+			return nil, false
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, true
+}
+
+// simplifyTree replaces groups holding a single node by that node.
+func simplifyTree(node *dsl.ConditionNode) *dsl.ConditionNode {
+	switch n := node.GetNode().(type) {
+	case *dsl.ConditionNode_Group_:
+		if len(n.Group.Children) == 1 {
+			return simplifyTree(n.Group.Children[0])
+		}
+		for i, child := range n.Group.Children {
+			n.Group.Children[i] = simplifyTree(child)
+		}
+	case *dsl.ConditionNode_Not:
+		n.Not = simplifyTree(n.Not)
+	}
+	return node
+}
+
+// splitChain flattens a chain of one logical operator into its operands without
+// looking through parentheses. A single operand is reported as an AND chain.
+func splitChain(e Expr) ([]Expr, dsl.GRuleJoinOperator) {
+	b, ok := e.(*BinaryExpr)
+	if !ok || !isLogical(b) {
+		return []Expr{e}, dsl.GRuleJoinOperator_AND
+	}
+	var collect func(e Expr) []Expr
+	collect = func(e Expr) []Expr {
+		if inner, ok := e.(*BinaryExpr); ok && inner.Op == b.Op {
+			return append(collect(inner.X), collect(inner.Y)...)
+		}
+		return []Expr{e}
+	}
+	return collect(b), logicalJoinOperators[b.Op]
+}
+
+// isConditionGroup reports whether a when clause operand is a whole condition
+// group rather than a single expression.
+func isConditionGroup(e Expr) bool {
+	if isLogical(unparen(e)) {
+		return true
+	}
+	p, ok := e.(*ParenExpr)
+	return ok && isParen(p.X)
+}
+
+func isLogical(e Expr) bool {
+	b, ok := e.(*BinaryExpr)
+	if !ok {
+		return false
+	}
+	_, ok = logicalJoinOperators[b.Op]
+	return ok
+}
+
+func isParen(e Expr) bool {
+	_, ok := e.(*ParenExpr)
+	return ok
+}
+
+// stripGroupParens removes the parentheses enclosing a chain or a group, but
+// keeps the single pair the serializer puts around each expression.
+func stripGroupParens(e Expr) Expr {
+	for {
+		p, ok := e.(*ParenExpr)
+		if !ok || !isLogical(p.X) && !isParen(p.X) {
+			return e
+		}
+		e = p.X
 	}
 }
 
-func extractValue(expr string, op dsl.GRuleExpressionOperator) string {
-	parts := strings.Split(expr, getGrlOperator(op))
-	if len(parts) != 2 {
-		return ""
+// expression maps `<field> <comparison> <literal>` or a call matching a
+// grl_operator template onto an expression. Anything else is reported and
+// dropped, in which case nil is returned.
+func (d *decompiler) expression(e Expr) (*dsl.EcommerceOfferRule_Condition_Expression, error) {
+	source := e
+	e = unparen(e)
+	for _, template := range operatorTemplates {
+		var b templateBinding
+		if !matchTemplate(template.pattern, e, &b) {
+			continue
+		}
+		input, ok := grlFieldToInputEnum[b.field]
+		if !ok {
+			d.report(source, formatExpr(source), "unknown input field "+b.field)
+			return nil, nil
+		}
+		if !IsOperatorApplicable(template.operator, getEnumGrlFieldType(input)) {
+			d.report(source, formatExpr(source), fmt.Sprintf("operator %s cannot be applied to %s", template.operator, b.field))
+			return nil, nil
+		}
+		val, err := replacedToRuleValue(b.field, input, template.operator, b.replaced)
+		if err != nil {
+			return nil, err
+		}
+		if template.operator == dsl.GRuleExpressionOperator_EQUALS_IGNORE_CASE && val.GetStringVal() != strings.ToLower(val.GetStringVal()) {
+			// the serializer lower-cases the value, which would make the
+			// comparison match where it never did
+			d.report(source, formatExpr(source), "compares a lower-cased field with a value that is not lower case")
+			return nil, nil
+		}
+		return &dsl.EcommerceOfferRule_Condition_Expression{
+			Input:    input,
+			Operator: template.operator,
+			Value:    val,
+		}, nil
+	}
+
+	if call, ok := e.(*CallExpr); ok {
+		if _, ok := dottedName(call.Fun); !ok {
+			d.report(source, formatExpr(source), "called function is not a name")
+			return nil, nil
+		}
+	}
+	cmp, ok := e.(*BinaryExpr)
+	if !ok {
+		d.report(source, formatExpr(source), "unsupported expression")
+		return nil, nil
+	}
+	operator, ok := comparisonOperators[cmp.Op]
+	if !ok {
+		d.report(source, formatExpr(source), "unsupported operator "+cmp.Op)
+		return nil, nil
+	}
+	name, aggregate, ok := comparedField(cmp.X)
+	if !ok {
+		d.report(source, formatExpr(source), "left side is not a field")
+		return nil, nil
+	}
+	input, ok := grlFieldToInputEnum[name]
+	if !ok {
+		d.report(source, formatExpr(source), "unknown input field "+name)
+		return nil, nil
+	}
+	fieldType := getEnumGrlFieldType(input)
+	if aggregate != dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED {
+		if !IsAggregateApplicable(aggregate, fieldType) {
+			d.report(source, formatExpr(source), fmt.Sprintf("aggregate %s cannot be applied to %s", aggregate, name))
+			return nil, nil
+		}
+		fieldType = getEnumGrlFieldType(aggregate)
+	}
+	if !IsOperatorApplicable(operator, fieldType) {
+		d.report(source, formatExpr(source), fmt.Sprintf("operator %s cannot be applied to %s", operator, formatExpr(cmp.X)))
+		return nil, nil
+	}
+	val, err := operandValue(name, fieldType, cmp.Y)
+	if err != nil {
+		return nil, err
+	}
+	return &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:     input,
+		Operator:  operator,
+		Value:     val,
+		Aggregate: aggregate,
+	}, nil
+}
+
+// comparedField resolves the left side of a comparison: a field, or an
+// aggregate template applied to a field.
+func comparedField(e Expr) (string, dsl.GRuleAggregate, bool) {
+	if name, ok := dottedName(e); ok {
+		return name, dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED, true
+	}
+	for _, template := range aggregateTemplates {
+		var b templateBinding
+		if matchTemplate(template.pattern, e, &b) {
+			return b.field, template.aggregate, true
+		}
+	}
+	return "", dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED, false
+}
+
+// replacedToRuleValue converts the arguments matched by :replace. List fields
+// take their arguments as the elements of a string list, set operators as the
+// elements of a list of the type of the field.
+func replacedToRuleValue(field string, input dsl.EcommerceOfferRule_Condition_InputField, operator dsl.GRuleExpressionOperator, args []Expr) (*dsl.RuleValue, error) {
+	fieldType := getEnumGrlFieldType(input)
+	if !takesValue(operator) {
+		return nil, nil
+	}
+	if setOperators[operator] {
+		candidates := make([]*dsl.RuleValue, 0, len(args))
+		for _, arg := range args {
+			val, err := coerceLiteral(field, fieldType, arg)
+			if err != nil {
+				return nil, err
+			}
+			if val.GetDecimalVal() != "" {
+				// a float list holds float32 values only
+				return nil, &ValueCoercionError{Pos: arg.Position(), Field: field, Expected: fieldType, Literal: formatExpr(arg)}
+			}
+			candidates = append(candidates, val)
+		}
+		return candidateList(fieldType, candidates), nil
+	}
+	if fieldType != dsl.FieldType_STRING_LIST {
+		if len(args) != 1 {
+			return nil, &ValueCoercionError{Pos: args[len(args)-1].Position(), Field: field, Expected: fieldType, Literal: formatArgs(args)}
+		}
+		return coerceLiteral(field, fieldType, args[0])
+	}
+	return stringList(field, fieldType, args)
+}
+
+// candidateList builds the list of set operator candidates holding vals,
+// literals of fieldType.
+func candidateList(fieldType dsl.FieldType, vals []*dsl.RuleValue) *dsl.RuleValue {
+	switch fieldType {
+	case dsl.FieldType_INTEGER:
+		list := &dsl.IntList{Values: make([]int32, 0, len(vals))}
+		for _, val := range vals {
+			list.Values = append(list.Values, val.GetIntVal())
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_IntList{IntList: list}}
+	case dsl.FieldType_LONG:
+		list := &dsl.LongList{Values: make([]int64, 0, len(vals))}
+		for _, val := range vals {
+			list.Values = append(list.Values, int64(val.GetIntVal())+val.GetLongVal())
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_LongList{LongList: list}}
+	case dsl.FieldType_FLOAT:
+		list := &dsl.FloatList{Values: make([]float32, 0, len(vals))}
+		for _, val := range vals {
+			list.Values = append(list.Values, val.GetFloatVal())
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_FloatList{FloatList: list}}
+	case dsl.FieldType_DOUBLE:
+		list := &dsl.DoubleList{Values: make([]float64, 0, len(vals))}
+		for _, val := range vals {
+			list.Values = append(list.Values, float64(val.GetFloatVal())+val.GetDoubleVal())
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_DoubleList{DoubleList: list}}
+	default:
+		list := &dsl.StringList{Values: make([]string, 0, len(vals))}
+		for _, val := range vals {
+			list.Values = append(list.Values, val.GetStringVal())
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: list}}
 	}
-	return strings.TrimSpace(parts[1])
 }
 
-func parseValue(val string) *dsl.RuleValue {
-	val = strings.Trim(val, "\" ")
-	if b, err := strconv.ParseBool(val); err == nil {
-		return &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: b}}
+// foldMembership merges the EQUALS expressions an OR group holds on the same
+// field into a single IN expression, placed where the first of them was.
+// Decimals too precise for a float or double list are left alone.
+func foldMembership(group *dsl.ConditionNode_Group) {
+	if group.Operator != dsl.GRuleJoinOperator_OR {
+		return
 	}
-	if f, err := strconv.ParseFloat(val, 64); err == nil {
-		return &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: float32(f)}}
+	foldable := func(expr *dsl.EcommerceOfferRule_Condition_Expression) bool {
+		return expr != nil && expr.Operator == dsl.GRuleExpressionOperator_EQUALS &&
+			!isComputed(expr.Value) &&
+			IsOperatorApplicable(dsl.GRuleExpressionOperator_IN, getEnumGrlFieldType(expr.Input)) &&
+			ValueTypeOf(expr.Value) != dsl.ValueType_DECIMAL_VAL
 	}
-	if i, err := strconv.Atoi(val); err == nil {
-		return &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: int32(i)}}
+	counts := make(map[dsl.EcommerceOfferRule_Condition_InputField]int)
+	for _, child := range group.Children {
+		if expr := child.GetExpression(); foldable(expr) {
+			counts[expr.Input]++
+		}
+	}
+	folded := make(map[dsl.EcommerceOfferRule_Condition_InputField]*dsl.EcommerceOfferRule_Condition_Expression)
+	candidates := make(map[dsl.EcommerceOfferRule_Condition_InputField][]*dsl.RuleValue)
+	children := make([]*dsl.ConditionNode, 0, len(group.Children))
+	for _, child := range group.Children {
+		expr := child.GetExpression()
+		if !foldable(expr) || counts[expr.Input] < 2 {
+			children = append(children, child)
+			continue
+		}
+		if _, ok := folded[expr.Input]; !ok {
+			folded[expr.Input] = &dsl.EcommerceOfferRule_Condition_Expression{Input: expr.Input, Operator: dsl.GRuleExpressionOperator_IN}
+			children = append(children, expressionNode(folded[expr.Input]))
+		}
+		candidates[expr.Input] = append(candidates[expr.Input], expr.Value)
+	}
+	for input, in := range folded {
+		in.Value = candidateList(getEnumGrlFieldType(input), candidates[input])
 	}
-	return &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: val}}
+	group.Children = children
+	if len(children) == 1 {
+		group.Operator = dsl.GRuleJoinOperator_AND
+	}
+}
+
+// rangeKey identifies what a bound comparison compares.
+type rangeKey struct {
+	input     dsl.EcommerceOfferRule_Condition_InputField
+	aggregate dsl.GRuleAggregate
 }
 
-func getGrlOperator(op dsl.GRuleExpressionOperator) string {
-	switch op {
-	case dsl.GRuleExpressionOperator_LESS_THAN:
-		return " < "
-	case dsl.GRuleExpressionOperator_LESS_THAN_EQUALS:
-		return " <= "
-	case dsl.GRuleExpressionOperator_GREATER_THAN:
-		return " > "
-	case dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS:
-		return " >= "
-	case dsl.GRuleExpressionOperator_EQUALS:
-		return " == "
-	case dsl.GRuleExpressionOperator_NOT_EQUALS:
-		return " != "
+// foldRanges merges a lower and an upper bound comparison on the same field
+// into one range expression, placed where the first of them was: BETWEEN for
+// `field >= lower && field <= upper` and NOT_BETWEEN for
+// `field < lower || field > upper`. Fields compared with more than one lower or
+// upper bound, or with bounds that enclose nothing, are left alone.
+func foldRanges(group *dsl.ConditionNode_Group) {
+	var rangeOp dsl.GRuleExpressionOperator
+	var lowerOps, upperOps map[dsl.GRuleExpressionOperator]bool
+	switch group.Operator {
+	case dsl.GRuleJoinOperator_AND:
+		rangeOp = dsl.GRuleExpressionOperator_BETWEEN
+		lowerOps = map[dsl.GRuleExpressionOperator]bool{dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS: true, dsl.GRuleExpressionOperator_GREATER_THAN: true}
+		upperOps = map[dsl.GRuleExpressionOperator]bool{dsl.GRuleExpressionOperator_LESS_THAN_EQUALS: true, dsl.GRuleExpressionOperator_LESS_THAN: true}
+	case dsl.GRuleJoinOperator_OR:
+		rangeOp = dsl.GRuleExpressionOperator_NOT_BETWEEN
+		lowerOps = map[dsl.GRuleExpressionOperator]bool{dsl.GRuleExpressionOperator_LESS_THAN: true, dsl.GRuleExpressionOperator_LESS_THAN_EQUALS: true}
+		upperOps = map[dsl.GRuleExpressionOperator]bool{dsl.GRuleExpressionOperator_GREATER_THAN: true, dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS: true}
 	default:
-		return ""
+		return
+	}
+	lowers := make(map[rangeKey][]*dsl.EcommerceOfferRule_Condition_Expression)
+	uppers := make(map[rangeKey][]*dsl.EcommerceOfferRule_Condition_Expression)
+	for _, child := range group.Children {
+		expr := child.GetExpression()
+		if expr == nil {
+			continue
+		}
+		key := rangeKey{input: expr.Input, aggregate: expr.Aggregate}
+		switch {
+		case lowerOps[expr.Operator]:
+			lowers[key] = append(lowers[key], expr)
+		case upperOps[expr.Operator]:
+			uppers[key] = append(uppers[key], expr)
+		}
+	}
+	ranges := make(map[rangeKey]*dsl.EcommerceOfferRule_Condition_Expression)
+	for key, lower := range lowers {
+		upper := uppers[key]
+		if len(lower) != 1 || len(upper) != 1 {
+			continue
+		}
+		// BETWEEN includes a bound compared with >= or <=, NOT_BETWEEN one
+		// compared with < or >
+		lowerExclusive := lower[0].Operator == dsl.GRuleExpressionOperator_GREATER_THAN ||
+			lower[0].Operator == dsl.GRuleExpressionOperator_LESS_THAN_EQUALS
+		upperExclusive := upper[0].Operator == dsl.GRuleExpressionOperator_LESS_THAN ||
+			upper[0].Operator == dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS
+		bounds := &dsl.Range{
+			Lower:          lower[0].Value,
+			Upper:          upper[0].Value,
+			LowerExclusive: lowerExclusive,
+			UpperExclusive: upperExclusive,
+		}
+		if !isRangeOrdered(bounds) {
+			continue
+		}
+		ranges[key] = &dsl.EcommerceOfferRule_Condition_Expression{
+			Input:     key.input,
+			Operator:  rangeOp,
+			Value:     &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: bounds}},
+			Aggregate: key.aggregate,
+		}
+	}
+	if len(ranges) == 0 {
+		return
+	}
+	children := make([]*dsl.ConditionNode, 0, len(group.Children))
+	for _, child := range group.Children {
+		expr := child.GetExpression()
+		if expr == nil || !lowerOps[expr.Operator] && !upperOps[expr.Operator] {
+			children = append(children, child)
+			continue
+		}
+		key := rangeKey{input: expr.Input, aggregate: expr.Aggregate}
+		folded, ok := ranges[key]
+		if !ok {
+			children = append(children, child)
+			continue
+		}
+		if folded != nil {
+			children = append(children, expressionNode(folded))
+			ranges[key] = nil
+		}
+	}
+	group.Children = children
+	if len(children) == 1 {
+		group.Operator = dsl.GRuleJoinOperator_AND
+	}
+}
+
+// ValueCoercionError is returned when a GRL literal does not fit the
+// grl_field_type declared for the field it is compared with or assigned to.
+type ValueCoercionError struct {
+	Pos      Pos
+	Field    string
+	Expected dsl.FieldType
+	Literal  string
+}
+
+func (e *ValueCoercionError) Error() string {
+	return fmt.Sprintf("line %s: cannot use %s as %s value for %s", e.Pos, e.Literal, e.Expected, e.Field)
+}
+
+// operandValue converts the right side of a comparison or assignment: a
+// reference to an input field whose grl_field_type fieldType accepts,
+// arithmetic for a numeric field, or a literal.
+func operandValue(field string, fieldType dsl.FieldType, e Expr) (*dsl.RuleValue, error) {
+	if isArithmetic(e) {
+		if !numericFieldTypes[fieldType] {
+			return nil, &ValueCoercionError{Pos: e.Position(), Field: field, Expected: fieldType, Literal: formatExpr(e)}
+		}
+		val, err := arithmeticValue(field, e)
+		if err != nil {
+			return nil, err
+		}
+		result, err := checkArithmetic(field, "arithmetic_val", val.GetArithmeticVal())
+		if err != nil || !IsValueTypeAccepted(fieldType, fieldValueTypes[result]) {
+			return nil, &ValueCoercionError{Pos: e.Position(), Field: field, Expected: fieldType, Literal: formatExpr(e)}
+		}
+		return val, nil
+	}
+	name, ok := dottedName(unparen(e))
+	if !ok {
+		return coerceLiteral(field, fieldType, e)
+	}
+	ref, ok := grlFieldToInputEnum[name]
+	if !ok || !IsValueTypeAccepted(fieldType, fieldValueTypes[getEnumGrlFieldType(ref)]) {
+		return nil, &ValueCoercionError{Pos: e.Position(), Field: field, Expected: fieldType, Literal: formatExpr(e)}
+	}
+	return &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: ref}}, nil
+}
+
+// isArithmetic reports whether e is a binary arithmetic operation or a call of
+// an arithmetic template such as Helper.Max(...).
+func isArithmetic(e Expr) bool {
+	e = unparen(e)
+	if b, ok := e.(*BinaryExpr); ok {
+		_, ok = arithmeticOperators[b.Op]
+		return ok
+	}
+	for _, pattern := range arithmeticTemplates {
+		if matchTemplate(pattern, e, &templateBinding{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// arithmeticValue converts arithmetic into an arithmetic_val. A chain of one
+// binary operator, such as a - b - c, becomes a single Arithmetic; operations
+// in parentheses or of another operator become nested ones.
+func arithmeticValue(field string, e Expr) (*dsl.RuleValue, error) {
+	e = unparen(e)
+	a := &dsl.Arithmetic{}
+	var operands []Expr
+	if b, ok := e.(*BinaryExpr); ok {
+		a.Operator = arithmeticOperators[b.Op]
+		for {
+			operands = append([]Expr{b.Y}, operands...)
+			left, ok := b.X.(*BinaryExpr)
+			if !ok || left.Op != b.Op {
+				operands = append([]Expr{b.X}, operands...)
+				break
+			}
+			b = left
+		}
+	} else {
+		for op, pattern := range arithmeticTemplates {
+			var binding templateBinding
+			if matchTemplate(pattern, e, &binding) {
+				a.Operator = op
+				operands = binding.replaced
+				break
+			}
+		}
+	}
+	for _, operand := range operands {
+		val, err := arithmeticOperand(field, operand)
+		if err != nil {
+			return nil, err
+		}
+		a.Operands = append(a.Operands, val)
+	}
+	return &dsl.RuleValue{Value: &dsl.RuleValue_ArithmeticVal{ArithmeticVal: a}}, nil
+}
+
+// arithmeticOperand converts one operand of arithmetic: a number, a numeric
+// input field or nested arithmetic.
+func arithmeticOperand(field string, e Expr) (*dsl.RuleValue, error) {
+	if isArithmetic(e) {
+		return arithmeticValue(field, e)
+	}
+	if n, ok := unparen(e).(*NumberLit); ok {
+		if n.IsFloat {
+			return coerceLiteral(field, dsl.FieldType_FLOAT, e)
+		}
+		if val, err := coerceLiteral(field, dsl.FieldType_INTEGER, e); err == nil {
+			return val, nil
+		}
+		return coerceLiteral(field, dsl.FieldType_LONG, e)
+	}
+	if name, ok := dottedName(unparen(e)); ok {
+		if ref, ok := grlFieldToInputEnum[name]; ok && numericFieldTypes[getEnumGrlFieldType(ref)] {
+			return &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: ref}}, nil
+		}
+	}
+	return nil, &ValueCoercionError{Pos: e.Position(), Field: field, Expected: dsl.FieldType_FLOAT, Literal: formatExpr(e)}
+}
+
+// coerceLiteral converts a literal node into the RuleValue variant matching the
+// grl_field_type of the field it belongs to.
+func coerceLiteral(field string, fieldType dsl.FieldType, e Expr) (*dsl.RuleValue, error) {
+	lit := unparen(e)
+	fail := &ValueCoercionError{Pos: e.Position(), Field: field, Expected: fieldType, Literal: formatExpr(e)}
+	switch fieldType {
+	case dsl.FieldType_STRING:
+		if s, ok := lit.(*StringLit); ok {
+			return &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: s.Value}}, nil
+		}
+	case dsl.FieldType_BOOL:
+		if b, ok := lit.(*BoolLit); ok {
+			return &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: b.Value}}, nil
+		}
+	case dsl.FieldType_INTEGER:
+		if n, ok := lit.(*NumberLit); ok && !n.IsFloat {
+			if i, err := strconv.ParseInt(n.Raw, 0, 32); err == nil {
+				return &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: int32(i)}}, nil
+			}
+		}
+	case dsl.FieldType_LONG:
+		if n, ok := lit.(*NumberLit); ok && !n.IsFloat {
+			if i, err := strconv.ParseInt(n.Raw, 0, 64); err == nil {
+				return &dsl.RuleValue{Value: &dsl.RuleValue_LongVal{LongVal: i}}, nil
+			}
+		}
+	case dsl.FieldType_FLOAT:
+		if n, ok := lit.(*NumberLit); ok {
+			if f, err := parseNumber(n.Raw, 32); err == nil {
+				if !fitsFloat(n.Raw, formatFloat(float32(f))) && decimalPattern.MatchString(n.Raw) {
+					return &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: n.Raw}}, nil
+				}
+				return &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: float32(f)}}, nil
+			}
+		}
+	case dsl.FieldType_DOUBLE:
+		if n, ok := lit.(*NumberLit); ok {
+			if f, err := parseNumber(n.Raw, 64); err == nil {
+				if !fitsFloat(n.Raw, formatDouble(f)) && decimalPattern.MatchString(n.Raw) {
+					return &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: n.Raw}}, nil
+				}
+				return &dsl.RuleValue{Value: &dsl.RuleValue_DoubleVal{DoubleVal: f}}, nil
+			}
+		}
+	}
+	return nil, fail
+}
+
+// parseNumber parses an integer or float GRL literal as a float.
+func parseNumber(raw string, bitSize int) (float64, error) {
+	if i, err := strconv.ParseInt(raw, 0, 64); err == nil {
+		return float64(i), nil
+	}
+	return strconv.ParseFloat(raw, bitSize)
+}
+
+// fitsFloat reports whether formatted, a float parsed from raw as it is
+// written back, is the same number as raw, in which case the literal can be
+// kept as a float_val or double_val without rounding.
+func fitsFloat(raw, formatted string) bool {
+	want, ok := new(big.Rat).SetString(raw)
+	if !ok {
+		return true
+	}
+	got, _ := new(big.Rat).SetString(formatted)
+	return want.Cmp(got) == 0
+}
+
+func formatArgs(args []Expr) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		parts = append(parts, formatExpr(arg))
 	}
+	return strings.Join(parts, ", ")
 }
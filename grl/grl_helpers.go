@@ -0,0 +1,129 @@
+package grl
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/hyperjumptech/grule-rule-engine/ast"
+)
+
+// HelperFactName is the name generated rules call helper functions under.
+const HelperFactName = "Helper"
+
+// Helpers holds the functions grl_operator templates refer to as Helper.*.
+// Add it to every data context rules are executed with, see AddHelpers.
+type Helpers struct{}
+
+// AddHelpers registers Helpers in dc under HelperFactName.
+func AddHelpers(dc ast.IDataContext) error {
+	return dc.Add(HelperFactName, &Helpers{})
+}
+
+// In reports whether value equals one of candidates. Grule passes integer
+// literals as int64 and float literals as float64, so numbers are compared by
+// value whatever their Go type. A float32 field equals the literal it was set
+// from, 0.1 and not the float64 nearest to float32(0.1).
+func (Helpers) In(value interface{}, candidates ...interface{}) bool {
+	value = normalizeNumber(value)
+	for _, candidate := range candidates {
+		if normalizeNumber(candidate) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeNumber(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if rv.Kind() == reflect.Float32 {
+			f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', -1, 32), 64)
+		}
+		if f == float64(int64(f)) {
+			return int64(f)
+		}
+		return f
+	}
+	return v
+}
+
+// Count returns the number of elements of a list field, 0 for nil.
+func (Helpers) Count(list interface{}) int {
+	rv := reflect.ValueOf(list)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len()
+	}
+	return 0
+}
+
+// IsEmpty reports whether a list field has no elements.
+func (h Helpers) IsEmpty(list interface{}) bool {
+	return h.Count(list) == 0
+}
+
+// Min returns the smallest of values. Unlike grule's built-in Min it accepts
+// integer fields and literals as well as floats.
+func (Helpers) Min(values ...interface{}) float64 {
+	smallest := math.Inf(1)
+	for _, v := range values {
+		smallest = math.Min(smallest, toFloat("Min", v))
+	}
+	return smallest
+}
+
+// Max returns the largest of values, accepting integers as well as floats.
+func (Helpers) Max(values ...interface{}) float64 {
+	largest := math.Inf(-1)
+	for _, v := range values {
+		largest = math.Max(largest, toFloat("Max", v))
+	}
+	return largest
+}
+
+// toFloat converts a numeric argument of fn. Grule reports the panic of a
+// non-numeric argument as an error of the rule that called fn.
+func toFloat(fn string, v interface{}) float64 {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	panic(fmt.Sprintf("Helper.%s: %v is not a number", fn, v))
+}
+
+// Append returns list with values added at its end. Values that are lists
+// themselves, such as a list field, add each of their elements.
+func (Helpers) Append(list interface{}, values ...interface{}) []string {
+	result := appendElements(nil, list)
+	for _, v := range values {
+		result = appendElements(result, v)
+	}
+	return result
+}
+
+func appendElements(list []string, v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return list
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			list = append(list, fmt.Sprint(rv.Index(i).Interface()))
+		}
+		return list
+	}
+	return append(list, fmt.Sprint(v))
+}
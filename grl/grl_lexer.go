@@ -0,0 +1,370 @@
+package grl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Pos is a 1-based line/column position inside a GRL source.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokInt
+	tokFloat
+
+	// keywords, matched case-insensitively as in the grule grammar
+	tokRule
+	tokWhen
+	tokThen
+	tokSalience
+	tokTrue
+	tokFalse
+	tokNil
+
+	tokLBrace
+	tokRBrace
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokDot
+	tokComma
+	tokSemicolon
+
+	tokPlus
+	tokMinus
+	tokMul
+	tokDiv
+	tokMod
+	tokBitAnd
+	tokBitOr
+	tokAnd
+	tokOr
+	tokNot
+
+	tokAssign
+	tokPlusAssign
+	tokMinusAssign
+	tokMulAssign
+	tokDivAssign
+
+	tokEq
+	tokNotEq
+	tokGT
+	tokLT
+	tokGTE
+	tokLTE
+)
+
+var keywords = map[string]tokenKind{
+	"rule":     tokRule,
+	"when":     tokWhen,
+	"then":     tokThen,
+	"salience": tokSalience,
+	"true":     tokTrue,
+	"false":    tokFalse,
+	"nil":      tokNil,
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  Pos
+	// comments are the line comments between the previous token and this one
+	comments []*Comment
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+// lexer splits GRL source into tokens following the lexer rules of grule's grulev3.g4.
+type lexer struct {
+	src      string
+	offset   int
+	line     int
+	column   int
+	comments []*Comment
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, column: 1}
+}
+
+func (l *lexer) peekRune(ahead int) rune {
+	offset := l.offset
+	for i := 0; i < ahead; i++ {
+		if offset >= len(l.src) {
+			return utf8.RuneError
+		}
+		_, size := utf8.DecodeRuneInString(l.src[offset:])
+		offset += size
+	}
+	if offset >= len(l.src) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[offset:])
+	return r
+}
+
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.src[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+	return r
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Line: l.line, Column: l.column}
+}
+
+func (l *lexer) skipSpaceAndComments() error {
+	for l.offset < len(l.src) {
+		r := l.peekRune(0)
+		switch {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			l.advance()
+		case r == '/' && l.peekRune(1) == '/':
+			comment := &Comment{Slash: l.pos()}
+			l.advance()
+			l.advance()
+			begin := l.offset
+			for l.offset < len(l.src) && l.peekRune(0) != '\n' {
+				l.advance()
+			}
+			comment.Text = strings.TrimSuffix(l.src[begin:l.offset], "\r")
+			l.comments = append(l.comments, comment)
+		case r == '/' && l.peekRune(1) == '*':
+			start := l.pos()
+			l.advance()
+			l.advance()
+			for {
+				if l.offset >= len(l.src) {
+					return &SyntaxError{Pos: start, Msg: "unterminated block comment"}
+				}
+				if l.peekRune(0) == '*' && l.peekRune(1) == '/' {
+					l.advance()
+					l.advance()
+					break
+				}
+				l.advance()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *lexer) next() (token, error) {
+	tok, err := l.scan()
+	tok.comments, l.comments = l.comments, nil
+	return tok, err
+}
+
+func (l *lexer) scan() (token, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return token{}, err
+	}
+	start := l.pos()
+	if l.offset >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+	begin := l.offset
+	r := l.peekRune(0)
+
+	switch {
+	case isIdentStart(r):
+		for l.offset < len(l.src) && isIdentPart(l.peekRune(0)) {
+			l.advance()
+		}
+		text := l.src[begin:l.offset]
+		if kind, ok := keywords[strings.ToLower(text)]; ok {
+			return token{kind: kind, text: text, pos: start}, nil
+		}
+		return token{kind: tokIdent, text: text, pos: start}, nil
+	case r >= '0' && r <= '9', r == '.' && isDigit(l.peekRune(1)):
+		return l.number(start)
+	case r == '"' || r == '\'':
+		return l.string(start)
+	}
+
+	l.advance()
+	two := func(next rune, double, single tokenKind) (token, error) {
+		if l.peekRune(0) == next {
+			l.advance()
+			return token{kind: double, text: l.src[begin:l.offset], pos: start}, nil
+		}
+		return token{kind: single, text: l.src[begin:l.offset], pos: start}, nil
+	}
+	single := func(kind tokenKind) (token, error) {
+		return token{kind: kind, text: l.src[begin:l.offset], pos: start}, nil
+	}
+	switch r {
+	case '{':
+		return single(tokLBrace)
+	case '}':
+		return single(tokRBrace)
+	case '(':
+		return single(tokLParen)
+	case ')':
+		return single(tokRParen)
+	case '[':
+		return single(tokLBracket)
+	case ']':
+		return single(tokRBracket)
+	case '.':
+		return single(tokDot)
+	case ',':
+		return single(tokComma)
+	case ';':
+		return single(tokSemicolon)
+	case '%':
+		return single(tokMod)
+	case '+':
+		return two('=', tokPlusAssign, tokPlus)
+	case '-':
+		return two('=', tokMinusAssign, tokMinus)
+	case '*':
+		return two('=', tokMulAssign, tokMul)
+	case '/':
+		return two('=', tokDivAssign, tokDiv)
+	case '&':
+		return two('&', tokAnd, tokBitAnd)
+	case '|':
+		return two('|', tokOr, tokBitOr)
+	case '!':
+		return two('=', tokNotEq, tokNot)
+	case '=':
+		return two('=', tokEq, tokAssign)
+	case '>':
+		return two('=', tokGTE, tokGT)
+	case '<':
+		return two('=', tokLTE, tokLT)
+	}
+	return token{}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func (l *lexer) number(start Pos) (token, error) {
+	begin := l.offset
+	kind := tokInt
+	if l.peekRune(0) == '0' && (l.peekRune(1) == 'x' || l.peekRune(1) == 'X') {
+		l.advance()
+		l.advance()
+		for isHexDigit(l.peekRune(0)) {
+			l.advance()
+		}
+		return token{kind: tokInt, text: l.src[begin:l.offset], pos: start}, nil
+	}
+	for isDigit(l.peekRune(0)) {
+		l.advance()
+	}
+	if l.peekRune(0) == '.' && isDigit(l.peekRune(1)) {
+		kind = tokFloat
+		l.advance()
+		for isDigit(l.peekRune(0)) {
+			l.advance()
+		}
+	}
+	if e := l.peekRune(0); e == 'e' || e == 'E' {
+		sign := l.peekRune(1)
+		if isDigit(sign) || ((sign == '+' || sign == '-') && isDigit(l.peekRune(2))) {
+			kind = tokFloat
+			l.advance()
+			l.advance()
+			for isDigit(l.peekRune(0)) {
+				l.advance()
+			}
+		}
+	}
+	return token{kind: kind, text: l.src[begin:l.offset], pos: start}, nil
+}
+
+func (l *lexer) string(start Pos) (token, error) {
+	begin := l.offset
+	quote := l.advance()
+	for {
+		if l.offset >= len(l.src) {
+			return token{}, &SyntaxError{Pos: start, Msg: "unterminated string literal"}
+		}
+		r := l.advance()
+		if r == '\\' {
+			if l.offset >= len(l.src) {
+				return token{}, &SyntaxError{Pos: start, Msg: "unterminated string literal"}
+			}
+			l.advance()
+			continue
+		}
+		if r == quote {
+			// a doubled quote is an escaped quote in the grule grammar
+			if l.peekRune(0) == quote {
+				l.advance()
+				continue
+			}
+			return token{kind: tokString, text: l.src[begin:l.offset], pos: start}, nil
+		}
+	}
+}
+
+// unquoteGRLString decodes a quoted GRL string literal the same way grule does.
+func unquoteGRLString(raw string) (string, error) {
+	if len(raw) < 2 {
+		return "", strconv.ErrSyntax
+	}
+	quote := raw[0]
+	body := raw[1 : len(raw)-1]
+	body = strings.ReplaceAll(body, string([]byte{quote, quote}), `\`+string(quote))
+	buf := make([]byte, 0, len(body))
+	for len(body) > 0 {
+		r, multibyte, rest, err := strconv.UnquoteChar(body, quote)
+		if err != nil {
+			return "", err
+		}
+		if r < utf8.RuneSelf || !multibyte {
+			buf = append(buf, byte(r))
+		} else {
+			buf = utf8.AppendRune(buf, r)
+		}
+		body = rest
+	}
+	return string(buf), nil
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isHexDigit(r rune) bool {
+	return isDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
@@ -0,0 +1,81 @@
+package grl
+
+import (
+	"fmt"
+	"strings"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// Keys of the `// @key: value` comment lines RuleMetadata is written as, the
+// proto field names. labels and notes take one line per label and per line of
+// notes.
+const (
+	metadataOwnerTeam = "owner_team"
+	metadataTicket    = "ticket"
+	metadataCreatedBy = "created_by"
+	metadataLabels    = "labels"
+	metadataNotes     = "notes"
+)
+
+// metadataComments renders the metadata of rule as the comment lines of the
+// GRuleEntity, without the leading slashes.
+func metadataComments(rule *dsl.EcommerceOfferRule) ([]string, error) {
+	m := rule.Metadata
+	if m == nil {
+		return nil, nil
+	}
+	var comments []string
+	line := func(key, value string) {
+		if value == "" {
+			// a blank line of notes
+			comments = append(comments, "@"+key+":")
+			return
+		}
+		comments = append(comments, fmt.Sprintf("@%s: %s", key, value))
+	}
+	for _, field := range []struct {
+		key   string
+		value string
+	}{{metadataOwnerTeam, m.OwnerTeam}, {metadataTicket, m.Ticket}, {metadataCreatedBy, m.CreatedBy}} {
+		if field.value == "" {
+			continue
+		}
+		if strings.ContainsAny(field.value, "\r\n") {
+			return nil, fmt.Errorf("rule %s: metadata.%s: contains a line break", rule.Name, field.key)
+		}
+		line(field.key, field.value)
+	}
+	for i, label := range m.Labels {
+		if strings.TrimSpace(label) == "" {
+			return nil, fmt.Errorf("rule %s: metadata.labels[%d]: empty label", rule.Name, i)
+		}
+		if strings.ContainsAny(label, "\r\n") {
+			return nil, fmt.Errorf("rule %s: metadata.labels[%d]: contains a line break", rule.Name, i)
+		}
+		line(metadataLabels, label)
+	}
+	if m.Notes != "" {
+		if strings.Contains(m.Notes, "\r") {
+			return nil, fmt.Errorf("rule %s: metadata.notes: contains a carriage return", rule.Name)
+		}
+		for _, note := range strings.Split(m.Notes, "\n") {
+			line(metadataNotes, note)
+		}
+	}
+	return comments, nil
+}
+
+// metadataLine splits a comment of the form `@key: value`, with or without a
+// space after the slashes, into its key and value.
+func metadataLine(text string) (string, string, bool) {
+	text, ok := strings.CutPrefix(strings.TrimPrefix(text, " "), "@")
+	if !ok {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(text, ":")
+	if !ok {
+		return "", "", false
+	}
+	return key, strings.TrimPrefix(value, " "), true
+}
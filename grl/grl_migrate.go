@@ -0,0 +1,105 @@
+package grl
+
+import (
+	"strconv"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// MigrateListValues rewrites the deprecated string_list_comma_concatenated
+// values of rule into the list type of the field they are used with: a
+// string_list for list fields and string candidates, an int_list, long_list,
+// float_list or double_list for numeric candidates. Blank elements are
+// dropped, as the serializer always did. Values whose elements are not valid for their field are left as they
+// are, so that converting the rule reports them.
+func MigrateListValues(rule *dsl.EcommerceOfferRule) {
+	for _, cond := range rule.Conditions {
+		for _, expr := range cond.Expressions {
+			migrateExpression(expr)
+		}
+	}
+	migrateNode(rule.ConditionTree)
+	for _, action := range rule.Actions {
+		if migrated, ok := migrateList(getEnumGrlFieldType(action.Output), action.Value); ok {
+			action.Value = migrated
+		}
+	}
+}
+
+func migrateNode(node *dsl.ConditionNode) {
+	switch n := node.GetNode().(type) {
+	case *dsl.ConditionNode_Expression:
+		migrateExpression(n.Expression)
+	case *dsl.ConditionNode_Group_:
+		for _, child := range n.Group.Children {
+			migrateNode(child)
+		}
+	case *dsl.ConditionNode_Not:
+		migrateNode(n.Not)
+	}
+}
+
+func migrateExpression(expr *dsl.EcommerceOfferRule_Condition_Expression) {
+	fieldType := getEnumGrlFieldType(expr.Input)
+	if !setOperators[expr.Operator] && fieldType != dsl.FieldType_STRING_LIST {
+		return
+	}
+	if migrated, ok := migrateList(fieldType, expr.Value); ok {
+		expr.Value = migrated
+	}
+}
+
+// migrateList converts a comma concatenated list holding elements of
+// fieldType, reporting false for any other value.
+func migrateList(fieldType dsl.FieldType, val *dsl.RuleValue) (*dsl.RuleValue, bool) {
+	list, ok := val.GetValue().(*dsl.RuleValue_StringListCommaConcatenated)
+	if !ok {
+		return nil, false
+	}
+	elements := listElements(list.StringListCommaConcatenated)
+	switch fieldType {
+	case dsl.FieldType_STRING, dsl.FieldType_STRING_LIST:
+		return &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: elements}}}, true
+	case dsl.FieldType_INTEGER:
+		values := make([]int32, 0, len(elements))
+		for _, e := range elements {
+			i, err := strconv.ParseInt(e, 10, 32)
+			if err != nil {
+				return nil, false
+			}
+			values = append(values, int32(i))
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_IntList{IntList: &dsl.IntList{Values: values}}}, true
+	case dsl.FieldType_LONG:
+		values := make([]int64, 0, len(elements))
+		for _, e := range elements {
+			i, err := strconv.ParseInt(e, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			values = append(values, i)
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_LongList{LongList: &dsl.LongList{Values: values}}}, true
+	case dsl.FieldType_FLOAT:
+		values := make([]float32, 0, len(elements))
+		for _, e := range elements {
+			f, err := strconv.ParseFloat(e, 32)
+			if err != nil || !decimalPattern.MatchString(e) || !fitsFloat(e, formatFloat(float32(f))) {
+				return nil, false
+			}
+			values = append(values, float32(f))
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_FloatList{FloatList: &dsl.FloatList{Values: values}}}, true
+	case dsl.FieldType_DOUBLE:
+		values := make([]float64, 0, len(elements))
+		for _, e := range elements {
+			f, err := strconv.ParseFloat(e, 64)
+			if err != nil || !decimalPattern.MatchString(e) || !fitsFloat(e, formatDouble(f)) {
+				return nil, false
+			}
+			values = append(values, f)
+		}
+		return &dsl.RuleValue{Value: &dsl.RuleValue_DoubleList{DoubleList: &dsl.DoubleList{Values: values}}}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,393 @@
+package grl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError is returned when GRL source cannot be parsed.
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %s: %s", e.Pos, e.Msg)
+}
+
+// parser is a recursive-descent parser for the subset of grule's grulev3.g4
+// grammar that rule entries are made of. Operator precedence follows the grammar:
+// multiplicative, additive, comparison, && and finally ||.
+//
+// Tokens are lexed as the parser reaches them, so that a lexer error only fails
+// the rule entry it occurs in.
+type parser struct {
+	src  string
+	lex  *lexer
+	toks []token
+	pos  int
+	// err is the lexer error that ended the token stream, reported instead
+	// of the syntax error it causes
+	err error
+}
+
+func newParser(src string) *parser {
+	return &parser{src: src, lex: newLexer(src)}
+}
+
+// parseRule parses a source holding exactly one rule entry.
+func parseRule(src string) (*RuleDecl, error) {
+	p := newParser(src)
+	decl, err := p.parseRuleDecl()
+	if err != nil {
+		return nil, p.failure(err)
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s after end of rule", tok)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return decl, nil
+}
+
+// ruleParse is the outcome of parsing one rule entry of a document.
+type ruleParse struct {
+	pos  Pos
+	name string
+	decl *RuleDecl
+	err  error
+}
+
+// ruleLinePattern matches a line starting with the rule keyword, where parsing
+// resumes after a rule entry failed.
+var ruleLinePattern = regexp.MustCompile(`(?mi)^[ \t]*rule\b`)
+
+// parseDocument parses every rule entry of a source. A rule that fails to
+// parse or lex is recorded with its error and parsing resumes at the next line
+// starting with the 'rule' keyword.
+func parseDocument(src string) []ruleParse {
+	p := newParser(src)
+	var entries []ruleParse
+	for p.peek().kind != tokEOF || p.err != nil {
+		entry := ruleParse{pos: p.peek().pos}
+		if p.peek().kind == tokRule && p.peekAt(1).kind == tokIdent {
+			entry.name = p.peekAt(1).text
+		}
+		entry.decl, entry.err = p.parseRuleDecl()
+		if entry.err != nil || p.err != nil {
+			entry.decl, entry.err = nil, p.failure(entry.err)
+			p.resume(entry.pos.Line + 1)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// resume restarts lexing at the first line from line on that starts with the
+// rule keyword, or at the end of the source.
+func (p *parser) resume(line int) {
+	offset := len(p.src)
+	start := 0
+	for i := 1; i < line && start < len(p.src); i++ {
+		next := strings.IndexByte(p.src[start:], '\n')
+		if next < 0 {
+			start = len(p.src)
+			break
+		}
+		start += next + 1
+	}
+	if loc := ruleLinePattern.FindStringIndex(p.src[start:]); loc != nil {
+		line += strings.Count(p.src[start:start+loc[0]], "\n")
+		offset = start + loc[0]
+	}
+	p.lex = &lexer{src: p.src, offset: offset, line: line, column: 1}
+	p.toks, p.pos, p.err = nil, 0, nil
+}
+
+// failure returns the lexer error that caused err, if any.
+func (p *parser) failure(err error) error {
+	if p.err != nil {
+		return p.err
+	}
+	return err
+}
+
+// peekAt returns the token ahead tokens after the current one. Once the lexer
+// failed, the stream ends with an EOF token at the failure.
+func (p *parser) peekAt(ahead int) token {
+	for len(p.toks) <= p.pos+ahead {
+		if n := len(p.toks); n > 0 && p.toks[n-1].kind == tokEOF {
+			return p.toks[n-1]
+		}
+		tok, err := p.lex.next()
+		if err != nil {
+			p.err = err
+			var syntaxErr *SyntaxError
+			tok = token{kind: tokEOF}
+			if errors.As(err, &syntaxErr) {
+				tok.pos = syntaxErr.Pos
+			}
+		}
+		p.toks = append(p.toks, tok)
+	}
+	return p.toks[p.pos+ahead]
+}
+
+func (p *parser) peek() token {
+	return p.peekAt(0)
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) accept(kind tokenKind) (token, bool) {
+	if p.peek().kind == kind {
+		return p.next(), true
+	}
+	return token{}, false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, p.errorf(tok, "expected %s, found %s", what, tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseRuleDecl() (*RuleDecl, error) {
+	ruleTok, err := p.expect(tokRule, "'rule'")
+	if err != nil {
+		return nil, err
+	}
+	nameTok, err := p.expect(tokIdent, "rule name")
+	if err != nil {
+		return nil, err
+	}
+	decl := &RuleDecl{RulePos: ruleTok.pos, Comments: ruleTok.comments, Name: nameTok.text}
+
+	if tok, ok := p.accept(tokString); ok {
+		desc, err := unquoteGRLString(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid rule description %s: %v", tok.text, err)
+		}
+		decl.Description = desc
+	}
+	if _, ok := p.accept(tokSalience); ok {
+		negative := false
+		if _, ok := p.accept(tokMinus); ok {
+			negative = true
+		}
+		tok, err := p.expect(tokInt, "integer salience")
+		if err != nil {
+			return nil, err
+		}
+		salience, err := strconv.ParseInt(tok.text, 0, 32)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid salience %s", tok.text)
+		}
+		if negative {
+			salience = -salience
+		}
+		decl.Salience = int(salience)
+	}
+
+	if _, err := p.expect(tokLBrace, "'{'"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokWhen, "'when'"); err != nil {
+		return nil, err
+	}
+	if decl.When, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokThen, "'then'"); err != nil {
+		return nil, err
+	}
+	for {
+		stmt, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+		decl.Then = append(decl.Then, stmt)
+		if _, err := p.expect(tokSemicolon, "';'"); err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(tokRBrace); ok {
+			return decl, nil
+		}
+	}
+}
+
+func (p *parser) parseStmt() (Stmt, error) {
+	lhs, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := p.peek(); tok.kind {
+	case tokAssign, tokPlusAssign, tokMinusAssign, tokMulAssign, tokDivAssign:
+		p.next()
+		if _, ok := dottedName(lhs); !ok {
+			return nil, p.errorf(tok, "left side of %s must be a variable", tok.text)
+		}
+		rhs, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &AssignStmt{Lhs: lhs, OpPos: tok.pos, Op: tok.text, Rhs: rhs}, nil
+	}
+	return &ExprStmt{X: lhs}, nil
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseBinary(0)
+}
+
+// binaryLevels lists the binary operators from the lowest to the highest precedence.
+var binaryLevels = [][]tokenKind{
+	{tokOr},
+	{tokAnd},
+	{tokEq, tokNotEq, tokGT, tokLT, tokGTE, tokLTE},
+	{tokPlus, tokMinus, tokBitAnd, tokBitOr},
+	{tokMul, tokDiv, tokMod},
+}
+
+func (p *parser) parseBinary(level int) (Expr, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if !containsKind(binaryLevels[level], tok.kind) {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &BinaryExpr{OpPos: tok.pos, Op: tok.text, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if tok, ok := p.accept(tokNot); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{OpPos: tok.pos, Op: tok.text, X: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch tok := p.peek(); tok.kind {
+		case tokDot:
+			p.next()
+			sel, err := p.expect(tokIdent, "member name")
+			if err != nil {
+				return nil, err
+			}
+			x = &SelectorExpr{X: x, Sel: &Ident{NamePos: sel.pos, Name: sel.text}}
+		case tokLParen:
+			p.next()
+			call := &CallExpr{Fun: x, Lparen: tok.pos}
+			if _, ok := p.accept(tokRParen); !ok {
+				for {
+					arg, err := p.parseExpr()
+					if err != nil {
+						return nil, err
+					}
+					call.Args = append(call.Args, arg)
+					if _, ok := p.accept(tokComma); !ok {
+						break
+					}
+				}
+				if _, err := p.expect(tokRParen, "')'"); err != nil {
+					return nil, err
+				}
+			}
+			x = call
+		case tokLBracket:
+			p.next()
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokRBracket, "']'"); err != nil {
+				return nil, err
+			}
+			x = &IndexExpr{X: x, Index: index}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokIdent:
+		return &Ident{NamePos: tok.pos, Name: tok.text}, nil
+	case tokString:
+		val, err := unquoteGRLString(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid string literal %s: %v", tok.text, err)
+		}
+		return &StringLit{ValuePos: tok.pos, Value: val, Raw: tok.text}, nil
+	case tokInt, tokFloat:
+		return &NumberLit{ValuePos: tok.pos, Raw: tok.text, IsFloat: tok.kind == tokFloat}, nil
+	case tokMinus:
+		num := p.next()
+		if num.kind != tokInt && num.kind != tokFloat {
+			return nil, p.errorf(num, "expected number after '-', found %s", num)
+		}
+		return &NumberLit{ValuePos: tok.pos, Raw: "-" + num.text, IsFloat: num.kind == tokFloat}, nil
+	case tokTrue, tokFalse:
+		return &BoolLit{ValuePos: tok.pos, Value: tok.kind == tokTrue}, nil
+	case tokNil:
+		return &NilLit{ValuePos: tok.pos}, nil
+	case tokLParen:
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return &ParenExpr{Lparen: tok.pos, X: x}, nil
+	}
+	return nil, p.errorf(tok, "unexpected %s in expression", tok)
+}
+
+func containsKind(kinds []tokenKind, kind tokenKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
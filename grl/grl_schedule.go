@@ -0,0 +1,88 @@
+package grl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"grule-protobuf-dsl/dsl"
+)
+
+// scheduleFunc is the Clock method a Schedule is rendered as, called with the
+// time zone followed by the weekday names and the "HH:MM-HH:MM" time ranges.
+const scheduleFunc = ClockFactName + ".InSchedule"
+
+var timeOfDayPattern = regexp.MustCompile(`^(?:[01]\d|2[0-3]):[0-5]\d$|^24:00$`)
+
+// parseTimeOfDay returns the minutes since midnight of a "HH:MM" time of day.
+func parseTimeOfDay(s string) (int, error) {
+	if !timeOfDayPattern.MatchString(s) {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	hours, _ := strconv.Atoi(s[:2])
+	minutes, _ := strconv.Atoi(s[3:])
+	return hours*60 + minutes, nil
+}
+
+// parseTimeRange returns the minutes since midnight a time range starts and
+// ends at.
+func parseTimeRange(start, end string) (int, int, error) {
+	from, err := parseTimeOfDay(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := parseTimeOfDay(end)
+	if err != nil {
+		return 0, 0, err
+	}
+	if to <= from {
+		return 0, 0, fmt.Errorf("time range %s-%s ends before it starts", start, end)
+	}
+	return from, to, nil
+}
+
+// checkSchedule validates the days, time ranges and time zone of s.
+func checkSchedule(rule, path string, s *dsl.Schedule) error {
+	if len(s.Days) == 0 && len(s.TimeRanges) == 0 {
+		return fmt.Errorf("rule %s: %s: schedule has neither days nor time ranges", rule, path)
+	}
+	for i, day := range s.Days {
+		if _, ok := dsl.Weekday_name[int32(day)]; !ok || day == dsl.Weekday_WEEKDAY_UNSPECIFIED {
+			return fmt.Errorf("rule %s: %s.days[%d]: unsupported weekday %s", rule, path, i, day)
+		}
+	}
+	for i, r := range s.TimeRanges {
+		if _, _, err := parseTimeRange(r.Start, r.End); err != nil {
+			return fmt.Errorf("rule %s: %s.time_ranges[%d]: %v", rule, path, i, err)
+		}
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("rule %s: %s.time_zone: unknown time zone %q", rule, path, s.TimeZone)
+	}
+	return nil
+}
+
+// scheduleText renders a validated Schedule as a call of scheduleFunc, e.g.
+// `Clock.InSchedule("Europe/Berlin", "FRIDAY", "18:00-21:00")`.
+func scheduleText(s *dsl.Schedule) string {
+	args := []string{strconv.Quote(s.TimeZone)}
+	for _, day := range s.Days {
+		args = append(args, strconv.Quote(day.String()))
+	}
+	for _, r := range s.TimeRanges {
+		args = append(args, strconv.Quote(r.Start+"-"+r.End))
+	}
+	return fmt.Sprintf("%s(%s)", scheduleFunc, strings.Join(args, ", "))
+}
+
+// scheduleWeekday returns the weekday named by the Weekday value name.
+func scheduleWeekday(name string) (time.Weekday, bool) {
+	day, ok := dsl.Weekday_value[name]
+	if !ok || day == int32(dsl.Weekday_WEEKDAY_UNSPECIFIED) {
+		return 0, false
+	}
+	// Weekday counts from MONDAY = 1 to SUNDAY = 7, time.Weekday from Sunday = 0
+	return time.Weekday(day % 7), true
+}
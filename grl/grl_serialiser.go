@@ -2,6 +2,7 @@ package grl
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,7 +13,7 @@ import (
 
 // EcommerceOfferRuleToGRuleEntity converts an EcommerceOfferRule to a GRuleEntity
 func EcommerceOfferRuleToGRuleEntity(rule *dsl.EcommerceOfferRule) (*GRuleEntity, error) {
-	if len(rule.Conditions) == 0 {
+	if len(rule.Conditions) == 0 && rule.ConditionTree == nil {
 		return nil, fmt.Errorf("no conditions defined")
 	}
 	if len(rule.Actions) == 0 {
@@ -20,41 +21,53 @@ func EcommerceOfferRuleToGRuleEntity(rule *dsl.EcommerceOfferRule) (*GRuleEntity
 	}
 
 	// Parse conditions to GRL 'when' clause
-	conditions := make([]string, 0)
-	for _, cond := range rule.Conditions {
-		expressions := make([]string, 0)
-		for _, expr := range cond.Expressions {
-			val, err := getRuleValue(expr.Value)
-			if err != nil {
-				return nil, err
-			}
-			op := getEnumGrlOperator(expr.Operator)
-			field := getEnumGrlFieldName(expr.Input)
-			if expr.Operator == dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION {
-				if val == "" {
-					return nil, fmt.Errorf("HAS_CATEGORY_FUNCTION used with empty list for field %s", field)
-				}
-				opStr := strings.Replace(op, ":field", field, 1)
-				exprStr := strings.Replace(opStr, ":replace", val, 1)
-				expressions = append(expressions, fmt.Sprintf("( %s )", exprStr))
-			} else {
-				expressions = append(expressions, fmt.Sprintf("( %s%s%s )", field, op, val))
-			}
-		}
-		joined := strings.Join(expressions, getEnumGrlOperator(cond.ExpressionJoinOperator))
-		conditions = append(conditions, joined)
+	when, err := whenClause(rule)
+	if err != nil {
+		return nil, err
 	}
-	when := strings.Join(conditions, getEnumGrlOperator(rule.ConditionJoinOperator))
 
 	// Parse actions to GRL 'then' clause
 	then := make([]string, 0, len(rule.Actions))
-	for _, action := range rule.Actions {
-		val, err := getRuleValue(action.Value)
+	changed := make([]string, 0, len(rule.Actions))
+	for i, action := range rule.Actions {
+		path := fmt.Sprintf("actions[%d]", i)
+		if err := checkActionOperator(rule.Name, path, action); err != nil {
+			return nil, err
+		}
+		if err := checkValueType(rule.Name, path, action.Output, action.Value); err != nil {
+			return nil, err
+		}
+		if err := checkPrecision(rule.Name, path, action.Output, action.Value); err != nil {
+			return nil, err
+		}
+		val, err := getRuleValue(action.Value, action.Output)
 		if err != nil {
 			return nil, err
 		}
-		actionStr := fmt.Sprintf("%s = %s;", getEnumGrlFieldName(action.Output), val)
-		then = append(then, actionStr)
+		field := getEnumGrlFieldName(action.Output)
+		if val == "" {
+			return nil, fmt.Errorf("%s used with empty list for field %s", action.Operator, field)
+		}
+		then = append(then, actionStatement(action.Operator, field, val))
+		if !slices.Contains(changed, field) {
+			changed = append(changed, field)
+		}
+	}
+
+	termination, ok := terminations[rule.TerminationMode]
+	if !ok {
+		return nil, fmt.Errorf("unsupported termination mode %s", rule.TerminationMode)
+	}
+	if rule.TerminationMode == dsl.GRuleTerminationMode_REFIRE {
+		// let the rules reading the assigned fields see the new values
+		for _, field := range changed {
+			then = append(then, fmt.Sprintf("Changed(%s);", EscapeGRLString(field)))
+		}
+	}
+
+	comments, err := metadataComments(rule)
+	if err != nil {
+		return nil, err
 	}
 
 	return &GRuleEntity{
@@ -63,10 +76,35 @@ func EcommerceOfferRuleToGRuleEntity(rule *dsl.EcommerceOfferRule) (*GRuleEntity
 		Salience:    strconv.Itoa(int(rule.Salience)),
 		When:        when,
 		Then:        then,
+		Termination: termination,
+		Comments:    comments,
 	}, nil
 }
 
-func getRuleValue(val *dsl.RuleValue) (string, error) {
+// actionStatement renders the assignment of val to field with op: written
+// between them for assignment operators such as +=, or assigning the result of
+// the grl_operator template, e.g. `Offer.X = Helper.Max(Offer.X, 5);`.
+func actionStatement(op dsl.EcommerceOfferRule_Action_Operator, field, val string) string {
+	template := getEnumGrlOperator(op)
+	if !strings.Contains(template, ":field") {
+		return fmt.Sprintf("%s%s%s;", field, template, val)
+	}
+	call := strings.Replace(strings.Replace(template, ":field", field, 1), ":replace", val, 1)
+	return fmt.Sprintf("%s = %s;", field, call)
+}
+
+// terminations maps the termination modes onto GRuleEntity.Termination.
+var terminations = map[dsl.GRuleTerminationMode]string{
+	dsl.GRuleTerminationMode_RETRACT_SELF:    TerminationRetractSelf,
+	dsl.GRuleTerminationMode_REFIRE:          TerminationRefire,
+	dsl.GRuleTerminationMode_COMPLETE_ENGINE: TerminationCompleteEngine,
+}
+
+// getRuleValue renders val as a GRL literal, as the grl_field_name of the
+// field it references or as GRL arithmetic. Numbers are written exactly, with the grl_precision of
+// field when it declares one.
+func getRuleValue(val *dsl.RuleValue, field protoreflect.Enum) (string, error) {
+	precision, hasPrecision := getEnumGrlPrecision(field)
 	switch v := val.Value.(type) {
 	case *dsl.RuleValue_StringVal:
 		return strconv.Quote(v.StringVal), nil
@@ -74,23 +112,110 @@ func getRuleValue(val *dsl.RuleValue) (string, error) {
 		return strconv.FormatBool(v.BoolVal), nil
 	case *dsl.RuleValue_IntVal:
 		return strconv.Itoa(int(v.IntVal)), nil
+	case *dsl.RuleValue_LongVal:
+		return strconv.FormatInt(v.LongVal, 10), nil
 	case *dsl.RuleValue_FloatVal:
-		return fmt.Sprintf("%.2f", v.FloatVal), nil
+		if hasPrecision {
+			return formatDecimal(formatFloat(v.FloatVal), precision), nil
+		}
+		return formatFloat(v.FloatVal), nil
+	case *dsl.RuleValue_DoubleVal:
+		if hasPrecision {
+			return formatDecimal(formatDouble(v.DoubleVal), precision), nil
+		}
+		return formatDouble(v.DoubleVal), nil
+	case *dsl.RuleValue_DecimalVal:
+		if hasPrecision {
+			return formatDecimal(v.DecimalVal, precision), nil
+		}
+		return v.DecimalVal, nil
+	case *dsl.RuleValue_FieldRef:
+		return getEnumGrlFieldName(v.FieldRef), nil
+	case *dsl.RuleValue_ArithmeticVal:
+		return arithmeticText(v.ArithmeticVal), nil
 	case *dsl.RuleValue_StringListCommaConcatenated:
-		elements := strings.Split(v.StringListCommaConcatenated, ",")
-		quoted := make([]string, 0, len(elements))
-		for _, e := range elements {
-			e = strings.TrimSpace(e)
-			if e != "" {
-				quoted = append(quoted, strconv.Quote(e))
+		return quoteElements(listElements(v.StringListCommaConcatenated)), nil
+	case *dsl.RuleValue_StringList:
+		return quoteElements(v.StringList.Values), nil
+	case *dsl.RuleValue_IntList:
+		elements := make([]string, 0, len(v.IntList.Values))
+		for _, i := range v.IntList.Values {
+			elements = append(elements, strconv.Itoa(int(i)))
+		}
+		return strings.Join(elements, ", "), nil
+	case *dsl.RuleValue_FloatList:
+		elements := make([]string, 0, len(v.FloatList.Values))
+		for _, f := range v.FloatList.Values {
+			text := formatFloat(f)
+			if hasPrecision {
+				text = formatDecimal(text, precision)
+			}
+			elements = append(elements, text)
+		}
+		return strings.Join(elements, ", "), nil
+	case *dsl.RuleValue_LongList:
+		elements := make([]string, 0, len(v.LongList.Values))
+		for _, i := range v.LongList.Values {
+			elements = append(elements, strconv.FormatInt(i, 10))
+		}
+		return strings.Join(elements, ", "), nil
+	case *dsl.RuleValue_DoubleList:
+		elements := make([]string, 0, len(v.DoubleList.Values))
+		for _, f := range v.DoubleList.Values {
+			text := formatDouble(f)
+			if hasPrecision {
+				text = formatDecimal(text, precision)
 			}
+			elements = append(elements, text)
 		}
-		return strings.Join(quoted, ", "), nil
+		return strings.Join(elements, ", "), nil
 	default:
 		return "", fmt.Errorf("unsupported rule value type")
 	}
 }
 
+func quoteElements(elements []string) string {
+	quoted := make([]string, 0, len(elements))
+	for _, e := range elements {
+		quoted = append(quoted, strconv.Quote(e))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// listElements splits a comma concatenated string list, dropping blank elements.
+func listElements(list string) []string {
+	elements := make([]string, 0)
+	for _, e := range strings.Split(list, ",") {
+		e = strings.TrimSpace(e)
+		if e != "" {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+// getCandidates renders the candidates of a set operator. Comma concatenated
+// candidates are quoted for string fields and written as they are for numeric
+// fields, typed lists are written like any other value.
+func getCandidates(val *dsl.RuleValue, field protoreflect.Enum) (string, error) {
+	list, ok := val.GetValue().(*dsl.RuleValue_StringListCommaConcatenated)
+	if !ok {
+		return getRuleValue(val, field)
+	}
+	elements := listElements(list.StringListCommaConcatenated)
+	switch getEnumGrlFieldType(field) {
+	case dsl.FieldType_STRING:
+		return quoteElements(elements), nil
+	case dsl.FieldType_FLOAT, dsl.FieldType_DOUBLE:
+		if precision, ok := getEnumGrlPrecision(field); ok {
+			for i, e := range elements {
+				elements[i] = formatDecimal(e, precision)
+			}
+		}
+	}
+	return strings.Join(elements, ", "), nil
+}
+
 func getEnumGrlFieldName(enum interface{ protoreflect.Enum }) string {
 	fieldName := proto.GetExtension(enum.Descriptor().Values().ByNumber(enum.Number()).Options(), dsl.E_GrlFieldName)
 	return fieldName.(string)
@@ -101,29 +226,130 @@ func getEnumGrlOperator(enum interface{ protoreflect.Enum }) string {
 	return fieldName.(string)
 }
 
-func getEnumGrlFieldType(enum interface{ protoreflect.Enum }) string {
-	fieldName := proto.GetExtension(enum.Descriptor().Values().ByNumber(enum.Number()).Options(), dsl.E_GrlFieldType)
-	return fieldName.(string)
+func getEnumGrlFieldType(enum interface{ protoreflect.Enum }) dsl.FieldType {
+	fieldType := proto.GetExtension(enum.Descriptor().Values().ByNumber(enum.Number()).Options(), dsl.E_GrlFieldType)
+	return fieldType.(dsl.FieldType)
+}
+
+// getEnumGrlPrecision returns the grl_precision of enum, if it declares one.
+func getEnumGrlPrecision(enum interface{ protoreflect.Enum }) (int, bool) {
+	opts := enum.Descriptor().Values().ByNumber(enum.Number()).Options()
+	if !proto.HasExtension(opts, dsl.E_GrlPrecision) {
+		return 0, false
+	}
+	return int(proto.GetExtension(opts, dsl.E_GrlPrecision).(int32)), true
+}
+
+// ValidateRuleName checks that name can be used as a GRL rule name: an ASCII
+// letter followed by ASCII letters, digits or underscores, and not a GRL keyword.
+func ValidateRuleName(name string) error {
+	if name == "" {
+		return fmt.Errorf("rule name is empty")
+	}
+	for i, r := range name {
+		if i == 0 && !isASCIILetter(r) {
+			return fmt.Errorf("rule name %q must start with a letter", name)
+		}
+		if !isRuleNameChar(r) {
+			return fmt.Errorf("rule name %q contains invalid character %q", name, r)
+		}
+	}
+	if _, ok := keywords[strings.ToLower(name)]; ok {
+		return fmt.Errorf("rule name %q is a GRL keyword", name)
+	}
+	return nil
+}
+
+// SanitizeRuleName derives a valid GRL rule name from an arbitrary string. Runs
+// of invalid characters become a single underscore, a leading non-letter gets an
+// "R_" prefix and keywords get an underscore suffix, so the same input always
+// produces the same identifier.
+func SanitizeRuleName(name string) string {
+	var sb strings.Builder
+	pendingUnderscore := false
+	for _, r := range name {
+		if isRuleNameChar(r) {
+			if pendingUnderscore && sb.Len() > 0 {
+				sb.WriteRune('_')
+			}
+			pendingUnderscore = false
+			sb.WriteRune(r)
+			continue
+		}
+		pendingUnderscore = true
+	}
+	sanitized := sb.String()
+	if sanitized == "" || !isASCIILetter(rune(sanitized[0])) {
+		sanitized = "R_" + sanitized
+	}
+	if _, ok := keywords[strings.ToLower(sanitized)]; ok {
+		sanitized += "_"
+	}
+	return sanitized
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isRuleNameChar(r rune) bool {
+	return isASCIILetter(r) || isDigit(r) || r == '_'
+}
+
+// EscapeGRLString renders s as a double-quoted GRL string literal.
+func EscapeGRLString(s string) string {
+	return strconv.Quote(s)
 }
 
 // ToGRL converts a GRuleEntity to a GRL string
-func ToGRL(grule *GRuleEntity) string {
-	return fmt.Sprintf(`rule %s "%s" salience %s {
+func ToGRL(grule *GRuleEntity) (string, error) {
+	if err := ValidateRuleName(grule.Name); err != nil {
+		return "", err
+	}
+	if _, err := strconv.Atoi(grule.Salience); err != nil {
+		return "", fmt.Errorf("rule %s: invalid salience %q", grule.Name, grule.Salience)
+	}
+	var header strings.Builder
+	for _, comment := range grule.Comments {
+		if strings.ContainsAny(comment, "\r\n") {
+			return "", fmt.Errorf("rule %s: comment %q spans several lines", grule.Name, comment)
+		}
+		header.WriteString("// " + comment + "\n")
+	}
+	then := slices.Clone(grule.Then)
+	switch grule.Termination {
+	case "", TerminationRetractSelf:
+		then = append(then, fmt.Sprintf("Retract(%s);", EscapeGRLString(grule.Name)))
+	case TerminationCompleteEngine:
+		then = append(then, "Complete();")
+	case TerminationRefire:
+	default:
+		return "", fmt.Errorf("rule %s: unknown termination %q", grule.Name, grule.Termination)
+	}
+	return fmt.Sprintf(`%srule %s %s salience %s {
 	when
 		%s
 	then
 		%s
-		Retract("%s");
 }`,
-		grule.Name, grule.Description, grule.Salience, grule.When, strings.Join(grule.Then, "\n\t\t"), grule.Name)
+		header.String(), grule.Name, EscapeGRLString(grule.Description), grule.Salience, grule.When, strings.Join(then, "\n\t\t")), nil
 }
 
 // ToMultipleGRLs converts a slice of GRuleEntity to a GRL string
-func ToMultipleGRLs(rules []*GRuleEntity) string {
+func ToMultipleGRLs(rules []*GRuleEntity) (string, error) {
 	var sb strings.Builder
+	seen := make(map[string]bool, len(rules))
 	for _, rule := range rules {
-		sb.WriteString(ToGRL(rule))
+		if seen[rule.Name] {
+			return "", fmt.Errorf("duplicate rule name %s", rule.Name)
+		}
+		seen[rule.Name] = true
+		grl, err := ToGRL(rule)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(grl)
 		sb.WriteString("\n")
 	}
-	return sb.String()
+	return sb.String(), nil
 }
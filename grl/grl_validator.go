@@ -0,0 +1,609 @@
+package grl
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"grule-protobuf-dsl/dsl"
+)
+
+// acceptedValueTypes lists the RuleValue types each grl_field_type accepts.
+// Integers may be used for float fields and narrower values for wider fields,
+// the reverse is truncated or rounded by grule and therefore rejected.
+var acceptedValueTypes = map[dsl.FieldType][]dsl.ValueType{
+	dsl.FieldType_STRING:      {dsl.ValueType_STRING_VAL},
+	dsl.FieldType_BOOL:        {dsl.ValueType_BOOL_VAL},
+	dsl.FieldType_INTEGER:     {dsl.ValueType_INTEGER_VAL},
+	dsl.FieldType_LONG:        {dsl.ValueType_LONG_VAL, dsl.ValueType_INTEGER_VAL},
+	dsl.FieldType_FLOAT:       {dsl.ValueType_FLOAT_VAL, dsl.ValueType_INTEGER_VAL, dsl.ValueType_DECIMAL_VAL},
+	dsl.FieldType_DOUBLE:      {dsl.ValueType_DOUBLE_VAL, dsl.ValueType_FLOAT_VAL, dsl.ValueType_LONG_VAL, dsl.ValueType_INTEGER_VAL, dsl.ValueType_DECIMAL_VAL},
+	dsl.FieldType_STRING_LIST: {dsl.ValueType_STRING_LIST_VAL},
+}
+
+// fieldValueTypes maps a grl_field_type onto the ValueType of its literals, so
+// that a referenced field is checked like a literal of its type.
+var fieldValueTypes = map[dsl.FieldType]dsl.ValueType{
+	dsl.FieldType_STRING:      dsl.ValueType_STRING_VAL,
+	dsl.FieldType_BOOL:        dsl.ValueType_BOOL_VAL,
+	dsl.FieldType_INTEGER:     dsl.ValueType_INTEGER_VAL,
+	dsl.FieldType_LONG:        dsl.ValueType_LONG_VAL,
+	dsl.FieldType_FLOAT:       dsl.ValueType_FLOAT_VAL,
+	dsl.FieldType_DOUBLE:      dsl.ValueType_DOUBLE_VAL,
+	dsl.FieldType_STRING_LIST: dsl.ValueType_STRING_LIST_VAL,
+}
+
+// ValueTypeOf returns the ValueType of the variant set in val.
+func ValueTypeOf(val *dsl.RuleValue) dsl.ValueType {
+	switch val.GetValue().(type) {
+	case *dsl.RuleValue_StringVal:
+		return dsl.ValueType_STRING_VAL
+	case *dsl.RuleValue_BoolVal:
+		return dsl.ValueType_BOOL_VAL
+	case *dsl.RuleValue_IntVal:
+		return dsl.ValueType_INTEGER_VAL
+	case *dsl.RuleValue_FloatVal:
+		return dsl.ValueType_FLOAT_VAL
+	case *dsl.RuleValue_LongVal:
+		return dsl.ValueType_LONG_VAL
+	case *dsl.RuleValue_DoubleVal:
+		return dsl.ValueType_DOUBLE_VAL
+	case *dsl.RuleValue_StringListCommaConcatenated, *dsl.RuleValue_StringList:
+		return dsl.ValueType_STRING_LIST_VAL
+	case *dsl.RuleValue_IntList:
+		return dsl.ValueType_INT_LIST_VAL
+	case *dsl.RuleValue_FloatList:
+		return dsl.ValueType_FLOAT_LIST_VAL
+	case *dsl.RuleValue_LongList:
+		return dsl.ValueType_LONG_LIST_VAL
+	case *dsl.RuleValue_DoubleList:
+		return dsl.ValueType_DOUBLE_LIST_VAL
+	case *dsl.RuleValue_DecimalVal:
+		return dsl.ValueType_DECIMAL_VAL
+	case *dsl.RuleValue_RangeVal:
+		return dsl.ValueType_RANGE_VAL
+	case *dsl.RuleValue_FieldRef:
+		return dsl.ValueType_FIELD_REF_VAL
+	case *dsl.RuleValue_ArithmeticVal:
+		return dsl.ValueType_ARITHMETIC_VAL
+	default:
+		return dsl.ValueType_VALUE_TYPE_UNSPECIFIED
+	}
+}
+
+// IsValueTypeAccepted reports whether a value of type valueType may be compared
+// with or assigned to a field of type fieldType.
+func IsValueTypeAccepted(fieldType dsl.FieldType, valueType dsl.ValueType) bool {
+	for _, accepted := range acceptedValueTypes[fieldType] {
+		if accepted == valueType {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeMismatchError is returned when a RuleValue does not match the
+// grl_field_type of the field it is used with.
+type TypeMismatchError struct {
+	Rule string
+	// Path locates the value inside the rule, e.g. "conditions[0].expressions[1]".
+	Path     string
+	Field    string
+	Expected dsl.FieldType
+	Actual   dsl.ValueType
+	// Ref and RefType describe the referenced field when the value is a
+	// field_ref, RefType is the computed type when it is an arithmetic_val.
+	Ref     string
+	RefType dsl.FieldType
+}
+
+func (e *TypeMismatchError) Error() string {
+	if e.Actual == dsl.ValueType_ARITHMETIC_VAL {
+		return fmt.Sprintf("rule %s: %s: %s expects a %s value, got %s arithmetic", e.Rule, e.Path, e.Field, e.Expected, e.RefType)
+	}
+	if e.Ref != "" {
+		return fmt.Sprintf("rule %s: %s: %s expects a %s value, got %s field %s", e.Rule, e.Path, e.Field, e.Expected, e.RefType, e.Ref)
+	}
+	return fmt.Sprintf("rule %s: %s: %s expects a %s value, got %s", e.Rule, e.Path, e.Field, e.Expected, e.Actual)
+}
+
+// checkValueType validates val against the grl_field_type of field.
+func checkValueType(rule, path string, field protoreflect.Enum, val *dsl.RuleValue) error {
+	return checkValueTypeAs(rule, path, getEnumGrlFieldName(field), getEnumGrlFieldType(field), val)
+}
+
+// checkValueTypeAs validates val against the expected field type. A field_ref
+// is checked as a literal of the grl_field_type of the referenced field, an
+// arithmetic_val as a literal of the type it computes.
+func checkValueTypeAs(rule, path, field string, expected dsl.FieldType, val *dsl.RuleValue) error {
+	actual := ValueTypeOf(val)
+	if actual == dsl.ValueType_ARITHMETIC_VAL {
+		result, err := checkArithmetic(rule, path+".arithmetic_val", val.GetArithmeticVal())
+		if err != nil {
+			return err
+		}
+		if !numericFieldTypes[expected] || !IsValueTypeAccepted(expected, fieldValueTypes[result]) {
+			return &TypeMismatchError{
+				Rule:     rule,
+				Path:     path,
+				Field:    field,
+				Expected: expected,
+				Actual:   actual,
+				RefType:  result,
+			}
+		}
+		return nil
+	}
+	if actual != dsl.ValueType_FIELD_REF_VAL {
+		if !IsValueTypeAccepted(expected, actual) {
+			return &TypeMismatchError{
+				Rule:     rule,
+				Path:     path,
+				Field:    field,
+				Expected: expected,
+				Actual:   actual,
+			}
+		}
+		return nil
+	}
+	refType := getEnumGrlFieldType(val.GetFieldRef())
+	if !IsValueTypeAccepted(expected, fieldValueTypes[refType]) {
+		return &TypeMismatchError{
+			Rule:     rule,
+			Path:     path,
+			Field:    field,
+			Expected: expected,
+			Actual:   actual,
+			Ref:      getEnumGrlFieldName(val.GetFieldRef()),
+			RefType:  refType,
+		}
+	}
+	return nil
+}
+
+// isComputed reports whether val is only known when the rule runs: a field
+// reference or an arithmetic value.
+func isComputed(val *dsl.RuleValue) bool {
+	switch ValueTypeOf(val) {
+	case dsl.ValueType_FIELD_REF_VAL, dsl.ValueType_ARITHMETIC_VAL:
+		return true
+	}
+	return false
+}
+
+// checkComputedValue rejects field references and arithmetic where only
+// literals can be used: the value of a template, set or range operator, which
+// are rendered from the literal itself.
+func checkComputedValue(rule, path string, expr *dsl.EcommerceOfferRule_Condition_Expression) error {
+	if !isComputed(expr.Value) {
+		return nil
+	}
+	if strings.Contains(getEnumGrlOperator(expr.Operator), ":field") || setOperators[expr.Operator] || rangeOperators[expr.Operator] {
+		what := "a field reference"
+		if ValueTypeOf(expr.Value) == dsl.ValueType_ARITHMETIC_VAL {
+			what = "an arithmetic value"
+		}
+		return fmt.Errorf("rule %s: %s: operator %s cannot be used with %s", rule, path, expr.Operator, what)
+	}
+	return nil
+}
+
+// expressionFieldType returns the type expr compares: the grl_field_type of
+// its aggregate if it has one, of its input field otherwise.
+func expressionFieldType(expr *dsl.EcommerceOfferRule_Condition_Expression) dsl.FieldType {
+	if expr.Aggregate != dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED {
+		return getEnumGrlFieldType(expr.Aggregate)
+	}
+	return getEnumGrlFieldType(expr.Input)
+}
+
+// decimalPattern is the accepted syntax of RuleValue.decimal_val.
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// PrecisionError is returned when a numeric value is not a valid decimal or has
+// more decimal places than the grl_precision of its field allows.
+type PrecisionError struct {
+	Rule      string
+	Path      string
+	Field     string
+	Precision int
+	Value     string
+}
+
+func (e *PrecisionError) Error() string {
+	if e.Precision < 0 {
+		return fmt.Sprintf("rule %s: %s: %q is not a valid decimal for %s", e.Rule, e.Path, e.Value, e.Field)
+	}
+	return fmt.Sprintf("rule %s: %s: %s has more than %d decimal places allowed for %s", e.Rule, e.Path, e.Value, e.Precision, e.Field)
+}
+
+// checkPrecision validates float and decimal values against the grl_precision
+// of field, so that writing them never rounds.
+func checkPrecision(rule, path string, field protoreflect.Enum, val *dsl.RuleValue) error {
+	var text string
+	switch v := val.GetValue().(type) {
+	case *dsl.RuleValue_FloatVal:
+		text = formatFloat(v.FloatVal)
+	case *dsl.RuleValue_DoubleVal:
+		text = formatDouble(v.DoubleVal)
+	case *dsl.RuleValue_DecimalVal:
+		if !decimalPattern.MatchString(v.DecimalVal) {
+			return &PrecisionError{Rule: rule, Path: path, Field: getEnumGrlFieldName(field), Precision: -1, Value: v.DecimalVal}
+		}
+		text = v.DecimalVal
+	default:
+		return nil
+	}
+	precision, ok := getEnumGrlPrecision(field)
+	if ok && decimalPlaces(text) > precision {
+		return &PrecisionError{Rule: rule, Path: path, Field: getEnumGrlFieldName(field), Precision: precision, Value: text}
+	}
+	return nil
+}
+
+// formatFloat renders f with the fewest digits that parse back to the same
+// float32, always keeping a decimal point.
+func formatFloat(f float32) string {
+	s := strconv.FormatFloat(float64(f), 'f', -1, 32)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// formatDouble renders f like formatFloat, with the digits of a float64.
+func formatDouble(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// formatDecimal writes the decimal text s with exactly precision places. The
+// caller guarantees s does not have more significant places than precision.
+func formatDecimal(s string, precision int) string {
+	whole, frac, _ := strings.Cut(s, ".")
+	frac = strings.TrimRight(frac, "0")
+	if precision == 0 {
+		return whole
+	}
+	return whole + "." + frac + strings.Repeat("0", precision-len(frac))
+}
+
+func decimalPlaces(s string) int {
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(s[i+1:], "0"))
+}
+
+// OperandFieldTypes returns the field types op can be applied to, as declared by
+// the grl_operand_types annotation of GRuleExpressionOperator.
+func OperandFieldTypes(op dsl.GRuleExpressionOperator) []dsl.FieldType {
+	return getEnumGrlOperandTypes(op)
+}
+
+func getEnumGrlOperandTypes(enum protoreflect.Enum) []dsl.FieldType {
+	desc := enum.Descriptor().Values().ByNumber(enum.Number())
+	if desc == nil {
+		return nil
+	}
+	return proto.GetExtension(desc.Options(), dsl.E_GrlOperandTypes).([]dsl.FieldType)
+}
+
+// IsOperatorApplicable reports whether op can be used on a field of fieldType.
+func IsOperatorApplicable(op dsl.GRuleExpressionOperator, fieldType dsl.FieldType) bool {
+	for _, ft := range OperandFieldTypes(op) {
+		if ft == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// OperatorMismatchError is returned when an expression operator is not
+// applicable to the grl_field_type of its input field.
+type OperatorMismatchError struct {
+	Rule      string
+	Path      string
+	Field     string
+	FieldType dsl.FieldType
+	Operator  dsl.GRuleExpressionOperator
+}
+
+func (e *OperatorMismatchError) Error() string {
+	return fmt.Sprintf("rule %s: %s: operator %s cannot be applied to %s field %s", e.Rule, e.Path, e.Operator, e.FieldType, e.Field)
+}
+
+// checkOperator validates the operator of expr against the type it compares.
+func checkOperator(rule, path string, expr *dsl.EcommerceOfferRule_Condition_Expression) error {
+	fieldType := expressionFieldType(expr)
+	if !IsOperatorApplicable(expr.Operator, fieldType) {
+		return &OperatorMismatchError{
+			Rule:      rule,
+			Path:      path,
+			Field:     getEnumGrlFieldName(expr.Input),
+			FieldType: fieldType,
+			Operator:  expr.Operator,
+		}
+	}
+	return nil
+}
+
+// IsActionOperatorApplicable reports whether op can be applied to an output
+// field of fieldType.
+func IsActionOperatorApplicable(op dsl.EcommerceOfferRule_Action_Operator, fieldType dsl.FieldType) bool {
+	for _, ft := range getEnumGrlOperandTypes(op) {
+		if ft == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// checkActionOperator validates the operator of action against the
+// grl_field_type of its output.
+func checkActionOperator(rule, path string, action *dsl.EcommerceOfferRule_Action) error {
+	fieldType := getEnumGrlFieldType(action.Output)
+	if !IsActionOperatorApplicable(action.Operator, fieldType) {
+		return fmt.Errorf("rule %s: %s: action operator %s cannot be applied to %s field %s", rule, path, action.Operator, fieldType, getEnumGrlFieldName(action.Output))
+	}
+	return nil
+}
+
+// PatternError is returned when the value of a MATCHES_REGEX expression is not
+// a valid regular expression.
+type PatternError struct {
+	Rule    string
+	Path    string
+	Pattern string
+	Err     error
+}
+
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("rule %s: %s: invalid regular expression %q: %v", e.Rule, e.Path, e.Pattern, e.Err)
+}
+
+func (e *PatternError) Unwrap() error {
+	return e.Err
+}
+
+// checkPattern compiles the value of a MATCHES_REGEX expression the same way
+// grule's MatchString does, so that broken patterns fail at conversion rather
+// than on every evaluation.
+func checkPattern(rule, path string, expr *dsl.EcommerceOfferRule_Condition_Expression) error {
+	if expr.Operator != dsl.GRuleExpressionOperator_MATCHES_REGEX {
+		return nil
+	}
+	pattern := expr.Value.GetStringVal()
+	if _, err := regexp.Compile(pattern); err != nil {
+		return &PatternError{Rule: rule, Path: path, Pattern: pattern, Err: err}
+	}
+	return nil
+}
+
+// setOperators compare a field with a list of candidates instead of one value.
+var setOperators = map[dsl.GRuleExpressionOperator]bool{
+	dsl.GRuleExpressionOperator_IN:     true,
+	dsl.GRuleExpressionOperator_NOT_IN: true,
+}
+
+// candidateListTypes lists the list types each grl_field_type accepts as the
+// candidates of a set operator. Like single values, integers may be candidates
+// of float fields and narrower lists candidates of wider fields.
+var candidateListTypes = map[dsl.FieldType][]dsl.ValueType{
+	dsl.FieldType_STRING:  {dsl.ValueType_STRING_LIST_VAL},
+	dsl.FieldType_INTEGER: {dsl.ValueType_INT_LIST_VAL},
+	dsl.FieldType_LONG:    {dsl.ValueType_LONG_LIST_VAL, dsl.ValueType_INT_LIST_VAL},
+	dsl.FieldType_FLOAT:   {dsl.ValueType_FLOAT_LIST_VAL, dsl.ValueType_INT_LIST_VAL},
+	dsl.FieldType_DOUBLE:  {dsl.ValueType_DOUBLE_LIST_VAL, dsl.ValueType_FLOAT_LIST_VAL, dsl.ValueType_LONG_LIST_VAL, dsl.ValueType_INT_LIST_VAL},
+}
+
+// listValueTypes are the value types holding a list of values.
+var listValueTypes = map[dsl.ValueType]bool{
+	dsl.ValueType_STRING_LIST_VAL: true,
+	dsl.ValueType_INT_LIST_VAL:    true,
+	dsl.ValueType_FLOAT_LIST_VAL:  true,
+	dsl.ValueType_LONG_LIST_VAL:   true,
+	dsl.ValueType_DOUBLE_LIST_VAL: true,
+}
+
+// checkCandidates validates the value of a set operator: a list of the type of
+// field, or a comma concatenated string list whose elements are all valid
+// values of field.
+func checkCandidates(rule, path string, field protoreflect.Enum, val *dsl.RuleValue) error {
+	fieldType := getEnumGrlFieldType(field)
+	actual := ValueTypeOf(val)
+	list, legacy := val.GetValue().(*dsl.RuleValue_StringListCommaConcatenated)
+	if !legacy {
+		if listValueTypes[actual] && !slices.Contains(candidateListTypes[fieldType], actual) {
+			return fmt.Errorf("rule %s: %s: %s candidates cannot be compared with %s field %s", rule, path, actual, fieldType, getEnumGrlFieldName(field))
+		}
+		if !listValueTypes[actual] {
+			return &TypeMismatchError{
+				Rule:     rule,
+				Path:     path,
+				Field:    getEnumGrlFieldName(field),
+				Expected: dsl.FieldType_STRING_LIST,
+				Actual:   actual,
+			}
+		}
+		for i, f := range val.GetFloatList().GetValues() {
+			element := &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: f}}
+			if err := checkPrecision(rule, fmt.Sprintf("%s.float_list.values[%d]", path, i), field, element); err != nil {
+				return err
+			}
+		}
+		for i, f := range val.GetDoubleList().GetValues() {
+			element := &dsl.RuleValue{Value: &dsl.RuleValue_DoubleVal{DoubleVal: f}}
+			if err := checkPrecision(rule, fmt.Sprintf("%s.double_list.values[%d]", path, i), field, element); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, candidate := range listElements(list.StringListCommaConcatenated) {
+		var err error
+		switch fieldType {
+		case dsl.FieldType_INTEGER:
+			_, err = strconv.ParseInt(candidate, 10, 32)
+		case dsl.FieldType_LONG:
+			_, err = strconv.ParseInt(candidate, 10, 64)
+		case dsl.FieldType_FLOAT, dsl.FieldType_DOUBLE:
+			decimal := &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: candidate}}
+			if err := checkPrecision(rule, path, field, decimal); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("rule %s: %s: candidate %q is not a valid %s for %s", rule, path, candidate, fieldType, getEnumGrlFieldName(field))
+		}
+	}
+	return nil
+}
+
+// rangeOperators compare a field with the two bounds of a Range.
+var rangeOperators = map[dsl.GRuleExpressionOperator]bool{
+	dsl.GRuleExpressionOperator_BETWEEN:     true,
+	dsl.GRuleExpressionOperator_NOT_BETWEEN: true,
+}
+
+// checkRange validates the value of a range operator: a Range whose bounds are
+// both set, valid values of the compared type and in ascending order.
+func checkRange(rule, path string, expr *dsl.EcommerceOfferRule_Condition_Expression) error {
+	field := getEnumGrlFieldName(expr.Input)
+	if actual := ValueTypeOf(expr.Value); actual != dsl.ValueType_RANGE_VAL {
+		return fmt.Errorf("rule %s: %s: operator %s expects a %s value, got %s", rule, path, expr.Operator, dsl.ValueType_RANGE_VAL, actual)
+	}
+	bounds := expr.Value.GetRangeVal()
+	for _, bound := range []struct {
+		name  string
+		value *dsl.RuleValue
+	}{{"lower", bounds.Lower}, {"upper", bounds.Upper}} {
+		boundPath := path + ".range_val." + bound.name
+		if bound.value == nil {
+			return fmt.Errorf("rule %s: %s: missing %s bound", rule, boundPath, bound.name)
+		}
+		if isComputed(bound.value) {
+			return fmt.Errorf("rule %s: %s: range bounds must be literals", rule, boundPath)
+		}
+		if err := checkValueTypeAs(rule, boundPath, field, expressionFieldType(expr), bound.value); err != nil {
+			return err
+		}
+		if err := checkPrecision(rule, boundPath, expr.Input, bound.value); err != nil {
+			return err
+		}
+	}
+	if !isRangeOrdered(bounds) {
+		return fmt.Errorf("rule %s: %s: range of %s is empty", rule, path, field)
+	}
+	return nil
+}
+
+// isRangeOrdered reports whether the bounds of r enclose at least one number:
+// lower is below upper, or equal to it with both bounds inclusive.
+func isRangeOrdered(r *dsl.Range) bool {
+	lower, ok := numericValue(r.Lower)
+	if !ok {
+		return false
+	}
+	upper, ok := numericValue(r.Upper)
+	if !ok {
+		return false
+	}
+	switch lower.Cmp(upper) {
+	case -1:
+		return true
+	case 0:
+		return !r.LowerExclusive && !r.UpperExclusive
+	default:
+		return false
+	}
+}
+
+// numericValue returns the exact number held by a numeric or decimal value.
+func numericValue(val *dsl.RuleValue) (*big.Rat, bool) {
+	var text string
+	switch v := val.GetValue().(type) {
+	case *dsl.RuleValue_IntVal:
+		text = strconv.Itoa(int(v.IntVal))
+	case *dsl.RuleValue_LongVal:
+		text = strconv.FormatInt(v.LongVal, 10)
+	case *dsl.RuleValue_FloatVal:
+		text = formatFloat(v.FloatVal)
+	case *dsl.RuleValue_DoubleVal:
+		text = formatDouble(v.DoubleVal)
+	case *dsl.RuleValue_DecimalVal:
+		text = v.DecimalVal
+	default:
+		return nil, false
+	}
+	return new(big.Rat).SetString(text)
+}
+
+// IsAggregateApplicable reports whether agg can be computed from a field of fieldType.
+func IsAggregateApplicable(agg dsl.GRuleAggregate, fieldType dsl.FieldType) bool {
+	for _, ft := range getEnumGrlOperandTypes(agg) {
+		if ft == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// AggregateMismatchError is returned when an aggregate is not applicable to the
+// grl_field_type of its input field, or is combined with an operator that does
+// not compare values.
+type AggregateMismatchError struct {
+	Rule      string
+	Path      string
+	Field     string
+	FieldType dsl.FieldType
+	Aggregate dsl.GRuleAggregate
+	Operator  dsl.GRuleExpressionOperator
+}
+
+func (e *AggregateMismatchError) Error() string {
+	if e.Operator != dsl.GRuleExpressionOperator_EXPRESSION_OPERATOR_UNSPECIFIED {
+		return fmt.Sprintf("rule %s: %s: aggregate %s cannot be used with operator %s", e.Rule, e.Path, e.Aggregate, e.Operator)
+	}
+	return fmt.Sprintf("rule %s: %s: aggregate %s cannot be applied to %s field %s", e.Rule, e.Path, e.Aggregate, e.FieldType, e.Field)
+}
+
+// checkAggregate validates the aggregate of expr, if any, against its input
+// field type. Aggregates are only compared with the relational operators.
+func checkAggregate(rule, path string, expr *dsl.EcommerceOfferRule_Condition_Expression) error {
+	if expr.Aggregate == dsl.GRuleAggregate_AGGREGATE_UNSPECIFIED {
+		return nil
+	}
+	fieldType := getEnumGrlFieldType(expr.Input)
+	fail := &AggregateMismatchError{
+		Rule:      rule,
+		Path:      path,
+		Field:     getEnumGrlFieldName(expr.Input),
+		FieldType: fieldType,
+		Aggregate: expr.Aggregate,
+	}
+	if !IsAggregateApplicable(expr.Aggregate, fieldType) {
+		return fail
+	}
+	if strings.Contains(getEnumGrlOperator(expr.Operator), ":field") {
+		fail.Operator = expr.Operator
+		return fail
+	}
+	return nil
+}
+
+// takesValue reports whether op compares the field with a value. Template
+// operators without a :replace placeholder, such as IS_EMPTY, do not.
+func takesValue(op dsl.GRuleExpressionOperator) bool {
+	template := getEnumGrlOperator(op)
+	return !strings.Contains(template, ":field") || strings.Contains(template, ":replace")
+}
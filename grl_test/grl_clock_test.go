@@ -0,0 +1,47 @@
+package grl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"grule-protobuf-dsl/dsl"
+	"grule-protobuf-dsl/grl"
+)
+
+func TestClock_Window(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := grl.NewClock(func() time.Time { return now })
+
+	assert.True(t, clock.NotBefore("2026-03-01T12:00:00Z"))
+	assert.False(t, clock.NotBefore("2026-03-01T12:00:01Z"))
+	assert.False(t, clock.Before("2026-03-01T12:00:00Z"))
+	assert.True(t, clock.Before("2026-03-01T14:00:00+01:00"))
+	assert.Panics(t, func() { clock.Before("tomorrow") })
+}
+
+func TestIsExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rule := &dsl.EcommerceOfferRule{}
+	assert.False(t, grl.IsExpired(rule, now))
+	rule.ValidUntil = timestamppb.New(now)
+	assert.True(t, grl.IsExpired(rule, now))
+	assert.False(t, grl.IsExpired(rule, now.Add(-time.Second)))
+}
+
+func TestClock_InSchedule(t *testing.T) {
+	// Friday 2026-03-06 17:30 UTC is 18:30 in Berlin
+	now := time.Date(2026, 3, 6, 17, 30, 0, 0, time.UTC)
+	clock := grl.NewClock(func() time.Time { return now })
+
+	assert.True(t, clock.InSchedule("Europe/Berlin", "FRIDAY", "18:00-21:00"))
+	assert.False(t, clock.InSchedule("", "FRIDAY", "18:00-21:00"))
+	assert.False(t, clock.InSchedule("Europe/Berlin", "SATURDAY", "SUNDAY"))
+	assert.True(t, clock.InSchedule("Europe/Berlin", "09:00-12:00", "18:00-18:31"))
+	assert.False(t, clock.InSchedule("Europe/Berlin", "18:00-18:30"), "the end of a range is exclusive")
+	assert.True(t, clock.InSchedule("Asia/Tokyo", "SATURDAY"), "it is already Saturday in Tokyo")
+	assert.Panics(t, func() { clock.InSchedule("Mars/Olympus", "FRIDAY") })
+	assert.Panics(t, func() { clock.InSchedule("UTC", "evenings") })
+}
@@ -1,12 +1,18 @@
 package grl_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"grule-protobuf-dsl/dsl"
 	"grule-protobuf-dsl/grl"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestParseGRLToRuleEntity(t *testing.T) {
@@ -212,3 +218,818 @@ func TestParseGRLToRuleEntity(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGRLToRuleEntity_TextThatBrokeTheRegexParser(t *testing.T) {
+	input := `rule TrickyText "Braces } and && in the description" salience 3 {
+	when
+		((Customer.Location == "A&&B") && (Customer.DeviceType == "x=y"))
+	then
+		Offer.PromoMessage = "Save 10% {today} && tomorrow";
+		Retract("TrickyText");
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Equal(t, "TrickyText", rule.Name)
+	assert.Equal(t, "Braces } and && in the description", rule.Description)
+	assert.Len(t, rule.Conditions, 1)
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+	assert.Equal(t, dsl.EcommerceOfferRule_Condition_LOCATION, rule.Conditions[0].Expressions[0].Input)
+	assert.Equal(t, "A&&B", rule.Conditions[0].Expressions[0].Value.GetStringVal())
+	assert.Equal(t, dsl.EcommerceOfferRule_Condition_DEVICE_TYPE, rule.Conditions[0].Expressions[1].Input)
+	assert.Equal(t, "x=y", rule.Conditions[0].Expressions[1].Value.GetStringVal())
+	assert.Len(t, rule.Actions, 1)
+	assert.Equal(t, "Save 10% {today} && tomorrow", rule.Actions[0].Value.GetStringVal())
+}
+
+func TestParseGRLToRuleEntity_EscapedStringsAndComments(t *testing.T) {
+	input := `// imported from the loyalty team
+rule Escaped "Say \"hi\"" salience 2 {
+	when
+		/* block comment */ Customer.Location == "Rome \"Centro\""
+	then
+		Offer.PromoMessage = 'It''s on';
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Equal(t, `Say "hi"`, rule.Description)
+	assert.Equal(t, `Rome "Centro"`, rule.Conditions[0].Expressions[0].Value.GetStringVal())
+	assert.Equal(t, "It's on", rule.Actions[0].Value.GetStringVal())
+}
+
+func TestParseGRLToRuleEntity_SyntaxErrorPosition(t *testing.T) {
+	input := `rule Broken "Missing operand" salience 1 {
+	when
+		Customer.Age >
+	then
+		Offer.FreeShipping = true;
+}`
+
+	_, err := grl.ParseGRLToRuleEntity(input)
+	var syntaxErr *grl.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, grl.Pos{Line: 4, Column: 2}, syntaxErr.Pos)
+}
+
+func TestDecompileGRL_ChainedMethodCalls(t *testing.T) {
+	input := `rule Chained "Calls on call results" salience 1 {
+	when
+		Customer.Location.ToLower().Contains("rome") && Customer.Gender.ToUpper().In("F", "M")[0] && Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+		Retract("Chained");
+}`
+
+	rule, warnings, err := grl.DecompileGRL(input, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, rule.Conditions[0].Expressions, 1)
+	if assert.Len(t, warnings, 2) {
+		assert.Equal(t, `Customer.Location.ToLower().Contains("rome")`, warnings[0].Text)
+		assert.Equal(t, "called function is not a name", warnings[0].Reason)
+		assert.Equal(t, `Customer.Gender.ToUpper().In("F", "M")[0]`, warnings[1].Text)
+	}
+}
+
+func TestDecompileGRL_IgnoreCaseWithUpperCaseValue(t *testing.T) {
+	input := `rule Berlin "Never matches" salience 1 {
+	when
+		Customer.Location.ToLower() == "BERLIN" && Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+		Retract("Berlin");
+}`
+
+	rule, warnings, err := grl.DecompileGRL(input, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, rule.Conditions[0].Expressions, 1) {
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_AGE, rule.Conditions[0].Expressions[0].Input)
+	}
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, `Customer.Location.ToLower() == "BERLIN"`, warnings[0].Text)
+		assert.Equal(t, "compares a lower-cased field with a value that is not lower case", warnings[0].Reason)
+	}
+
+	_, _, err = grl.DecompileGRL(input, grl.DecompileOptions{Strict: true})
+	var diags grl.Diagnostics
+	assert.ErrorAs(t, err, &diags)
+
+	rule, warnings, err = grl.DecompileGRL(strings.Replace(input, "BERLIN", "berlin", 1), grl.DecompileOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, dsl.GRuleExpressionOperator_EQUALS_IGNORE_CASE, rule.Conditions[0].Expressions[0].Operator)
+}
+
+func TestParseGRLToRuleEntity_OrAndNestedConditionGroups(t *testing.T) {
+	input := `rule Segments "Loyal big baskets or young mobile users" salience 7 {
+	when
+		( ( Customer.IsLoyaltyProgramMember == true ) && ( Customer.CartTotal > 500.00 ) ) || ( ( Customer.Age < 25 ) && ( Customer.DeviceType == "mobile" ) )
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Equal(t, dsl.GRuleJoinOperator_OR, rule.ConditionJoinOperator)
+	assert.Len(t, rule.Conditions, 2)
+	for _, cond := range rule.Conditions {
+		assert.Equal(t, dsl.GRuleJoinOperator_AND, cond.ExpressionJoinOperator)
+		assert.Len(t, cond.Expressions, 2)
+	}
+	assert.Equal(t, dsl.EcommerceOfferRule_Condition_AGE, rule.Conditions[1].Expressions[0].Input)
+}
+
+func TestParseGRLToRuleEntity_OrWithinSingleCondition(t *testing.T) {
+	input := `rule AnyDevice "Mobile or young" salience 1 {
+	when
+		Customer.DeviceType == "mobile" || Customer.Age < 25
+	then
+		Offer.PromoMessage = "App only deal";
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Len(t, rule.Conditions, 1)
+	assert.Equal(t, dsl.GRuleJoinOperator_OR, rule.Conditions[0].ExpressionJoinOperator)
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+}
+
+func TestParseGRLToRuleEntity_SameFieldOrChainFoldsToIn(t *testing.T) {
+	input := `rule Cities "Selected cities or mobile" salience 1 {
+	when
+		Customer.Location == "Berlin" || Customer.DeviceType == "mobile" || Customer.Location == "Paris" || Customer.Location == "Rome"
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 2) {
+		assert.Equal(t, dsl.GRuleExpressionOperator_IN, exprs[0].Operator)
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_LOCATION, exprs[0].Input)
+		assert.Equal(t, []string{"Berlin", "Paris", "Rome"}, exprs[0].Value.GetStringList().GetValues())
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_DEVICE_TYPE, exprs[1].Input)
+	}
+
+	input = `rule Ages "Round birthdays" salience 1 {
+	when
+		( Customer.Age == 30 ) || ( Customer.Age == 40 )
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err = grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs = rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 1) {
+		assert.Equal(t, dsl.GRuleExpressionOperator_IN, exprs[0].Operator)
+		assert.Equal(t, []int32{30, 40}, exprs[0].Value.GetIntList().GetValues())
+		assert.Equal(t, dsl.GRuleJoinOperator_AND, rule.Conditions[0].ExpressionJoinOperator)
+	}
+
+	input = `rule Whales "Large point balances" salience 1 {
+	when
+		Customer.LifetimeLoyaltyPoints == 5000000000 || Customer.LifetimeLoyaltyPoints == 7000000000
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err = grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs = rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 1) {
+		assert.Equal(t, dsl.GRuleExpressionOperator_IN, exprs[0].Operator)
+		assert.Equal(t, []int64{5000000000, 7000000000}, exprs[0].Value.GetLongList().GetValues())
+	}
+}
+
+func TestParseGRLToRuleEntity_LongAndDoubleCandidates(t *testing.T) {
+	input := `rule Whales "Large balances" salience 1 {
+	when
+		Helper.In(Customer.LifetimeLoyaltyPoints, 5000000000) && !Helper.In(Customer.TotalSpent, 0, 123456789.25)
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 2) {
+		assert.Equal(t, []int64{5000000000}, exprs[0].Value.GetLongList().GetValues())
+		assert.Equal(t, dsl.GRuleExpressionOperator_NOT_IN, exprs[1].Operator)
+		assert.Equal(t, []float64{0, 123456789.25}, exprs[1].Value.GetDoubleList().GetValues())
+	}
+}
+
+func TestParseGRLToRuleEntity_ListElementsContainingCommas(t *testing.T) {
+	input := `rule Games "Games shoppers" salience 1 {
+	when
+		Customer.LastCategoryPurchased == "Toys, Games & Puzzles" || Customer.LastCategoryPurchased == "Books"
+	then
+		Offer.PromoTags = Helper.Append(Offer.PromoTags, "Toys, Games & Puzzles");
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 1) {
+		assert.Equal(t, dsl.GRuleExpressionOperator_IN, exprs[0].Operator)
+		assert.Equal(t, []string{"Toys, Games & Puzzles", "Books"}, exprs[0].Value.GetStringList().GetValues())
+	}
+	if assert.Len(t, rule.Actions, 1) {
+		assert.Equal(t, []string{"Toys, Games & Puzzles"}, rule.Actions[0].Value.GetStringList().GetValues())
+	}
+}
+
+func TestParseGRLToRuleEntity_BoundsFoldToRange(t *testing.T) {
+	input := `rule AgeBand "Young adults with a mid-sized cart" salience 1 {
+	when
+		Customer.Age >= 18 && Customer.CartTotal > 50 && Customer.Age < 25 && Customer.CartTotal <= 200
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 2) {
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_AGE, exprs[0].Input)
+		assert.Equal(t, dsl.GRuleExpressionOperator_BETWEEN, exprs[0].Operator)
+		ages := exprs[0].Value.GetRangeVal()
+		assert.Equal(t, int32(18), ages.Lower.GetIntVal())
+		assert.Equal(t, int32(25), ages.Upper.GetIntVal())
+		assert.False(t, ages.LowerExclusive)
+		assert.True(t, ages.UpperExclusive)
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_CART_TOTAL, exprs[1].Input)
+		assert.Equal(t, dsl.GRuleExpressionOperator_BETWEEN, exprs[1].Operator)
+		assert.True(t, exprs[1].Value.GetRangeVal().LowerExclusive)
+	}
+
+	input = `rule OutsideBand "Not a young adult" salience 1 {
+	when
+		( ( Customer.Age < 18 || Customer.Age > 25 ) ) && ( ( Customer.IsLoyaltyProgramMember == true ) )
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err = grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	if assert.Len(t, rule.Conditions, 2) {
+		exprs = rule.Conditions[0].Expressions
+		if assert.Len(t, exprs, 1) {
+			assert.Equal(t, dsl.GRuleExpressionOperator_NOT_BETWEEN, exprs[0].Operator)
+			assert.False(t, exprs[0].Value.GetRangeVal().LowerExclusive)
+			assert.False(t, exprs[0].Value.GetRangeVal().UpperExclusive)
+		}
+	}
+
+	input = `rule NoBand "Bounds that enclose nothing" salience 1 {
+	when
+		Customer.Age > 30 && Customer.Age < 20
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err = grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+}
+
+func TestParseGRLToRuleEntity_PrecedenceFormsImplicitGroups(t *testing.T) {
+	input := `rule Precedence "a && b || c" salience 1 {
+	when
+		Customer.Age > 30 && Customer.Gender == "F" || Customer.IsLoyaltyProgramMember == true
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Equal(t, dsl.GRuleJoinOperator_OR, rule.ConditionJoinOperator)
+	assert.Len(t, rule.Conditions, 2)
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+	assert.Len(t, rule.Conditions[1].Expressions, 1)
+}
+
+func TestParseGRLToRuleEntity_DeepNestingBecomesTree(t *testing.T) {
+	input := `rule Deep "three levels" salience 1 {
+	when
+		( ( Customer.Age > 30 ) && ( ( Customer.Gender == "F" ) || ( Customer.LastCategoryPurchased == "Toys" ) ) ) || !( Customer.CartTotal > 10.00 )
+	then
+		Offer.FreeShipping = true;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Empty(t, rule.Conditions)
+	root := rule.ConditionTree.GetGroup()
+	if assert.NotNil(t, root) && assert.Len(t, root.Children, 2) {
+		assert.Equal(t, dsl.GRuleJoinOperator_OR, root.Operator)
+		left := root.Children[0].GetGroup()
+		assert.Equal(t, dsl.GRuleJoinOperator_AND, left.Operator)
+		assert.Equal(t, dsl.GRuleJoinOperator_OR, left.Children[1].GetGroup().Operator)
+		assert.Len(t, left.Children[1].GetGroup().Children, 2)
+		negated := root.Children[1].GetNot().GetExpression()
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_CART_TOTAL, negated.GetInput())
+	}
+}
+
+func TestParseGRLToRuleEntity_RoundTrip(t *testing.T) {
+	original := &dsl.EcommerceOfferRule{
+		Name:        "RoundTrip",
+		Description: "Serializer output decompiles into the same rule",
+		Salience:    9,
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_AGE,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 250.5}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+						Operator: dsl.GRuleExpressionOperator_EQUALS,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+						Operator: dsl.GRuleExpressionOperator_EQUALS,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "Berlin"}},
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_SIGNUP_DAYS_AGO,
+						Operator: dsl.GRuleExpressionOperator_LESS_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 30}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_OR,
+			},
+		},
+		ConditionJoinOperator: dsl.GRuleJoinOperator_OR,
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_ASSIGN_COUPON_CODE,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "WELCOME"}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(original)
+	assert.NoError(t, err)
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(original, decompiled), "got %v", decompiled)
+}
+
+func TestParseGRLToRuleEntity_HasCategory(t *testing.T) {
+	input := `rule CategoryPromo "Browsing electronics or home" salience 5 {
+	when
+		( Customer.HasCategory(Customer.BrowsingCategories, "Electronics", "Home") ) && ( Customer.Age > 18 )
+	then
+		Offer.PromoMessage = "Deals!";
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+	expr := rule.Conditions[0].Expressions[0]
+	assert.Equal(t, dsl.EcommerceOfferRule_Condition_BROWSING_CATEGORIES, expr.Input)
+	assert.Equal(t, dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION, expr.Operator)
+	assert.Equal(t, []string{"Electronics", "Home"}, expr.Value.GetStringList().GetValues())
+}
+
+func TestParseGRLToRuleEntity_CategorySetOperators(t *testing.T) {
+	input := `rule BundleDeal "Electronics with accessories, nothing adult" salience 5 {
+	when
+		( Customer.HasAllCategories(Customer.CartContainsCategories, "Electronics", "Accessories") ) && ( Customer.HasNoCategories(Customer.BrowsingCategories, "Adult") )
+	then
+		Offer.ApplyDiscountPercent = 5.0;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 2) {
+		assert.Equal(t, dsl.GRuleExpressionOperator_HAS_ALL_CATEGORIES, exprs[0].Operator)
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_CART_CONTAINS_CATEGORIES, exprs[0].Input)
+		assert.Equal(t, []string{"Electronics", "Accessories"}, exprs[0].Value.GetStringList().GetValues())
+		assert.Equal(t, dsl.GRuleExpressionOperator_HAS_NO_CATEGORIES, exprs[1].Operator)
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_BROWSING_CATEGORIES, exprs[1].Input)
+		assert.Equal(t, []string{"Adult"}, exprs[1].Value.GetStringList().GetValues())
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, `( Customer.HasAllCategories(Customer.CartContainsCategories, "Electronics", "Accessories") ) && ( Customer.HasNoCategories(Customer.BrowsingCategories, "Adult") )`, entity.When)
+}
+
+func TestParseGRLToRuleEntity_RulesDirectoryRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("../rules/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			var original dsl.EcommerceOfferRule
+			assert.NoError(t, protojson.Unmarshal(data, &original))
+			grl.MigrateListValues(&original)
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(&original)
+			assert.NoError(t, err)
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(&original, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestParseGRLToRuleEntity_FieldReferences(t *testing.T) {
+	input := `rule AboveAverage "Cart above the average order" salience 1 {
+	when
+		Customer.CartTotal > Customer.AvgOrderValue && Customer.Location == Customer.LastCategoryPurchased
+	then
+		Offer.ApplyFlatDiscount = Customer.ReturnRatePercent;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 2) {
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_AVG_ORDER_VALUE, exprs[0].Value.GetFieldRef())
+		assert.Equal(t, dsl.ValueType_FIELD_REF_VAL, grl.ValueTypeOf(exprs[1].Value))
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_LAST_CATEGORY_PURCHASED, exprs[1].Value.GetFieldRef())
+	}
+	assert.Equal(t, dsl.EcommerceOfferRule_Condition_RETURN_RATE_PERCENT, rule.Actions[0].Value.GetFieldRef())
+
+	input = `rule Mismatch "Integer field compared with a float field" salience 1 {
+	when
+		Customer.Age > Customer.CartTotal
+	then
+		Offer.FreeShipping = true;
+}`
+
+	_, err = grl.ParseGRLToRuleEntity(input)
+	var coercion *grl.ValueCoercionError
+	if assert.ErrorAs(t, err, &coercion) {
+		assert.Equal(t, "Customer.CartTotal", coercion.Literal)
+		assert.Equal(t, dsl.FieldType_INTEGER, coercion.Expected)
+	}
+}
+
+func TestParseGRLToRuleEntity_Arithmetic(t *testing.T) {
+	input := `rule Computed "Discount grows with the cart" salience 1 {
+	when
+		Customer.CartTotal - Customer.AvgOrderValue > 10 + Customer.Age * 2
+	then
+		Offer.ApplyFlatDiscount = Customer.CartTotal - Customer.AvgOrderValue - 5;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	// the left side of a comparison must be a field
+	assert.Empty(t, rule.Conditions)
+
+	input = `rule Computed "Discount grows with the cart" salience 1 {
+	when
+		Customer.CartTotal > 10 + Customer.Age * 2
+	then
+		Offer.ApplyFlatDiscount = Customer.CartTotal - Customer.AvgOrderValue - 5;
+}`
+
+	rule, err = grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	sum := rule.Conditions[0].Expressions[0].Value.GetArithmeticVal()
+	if assert.NotNil(t, sum) {
+		assert.Equal(t, dsl.ArithmeticOperator_ADD, sum.Operator)
+		assert.Equal(t, int32(10), sum.Operands[0].GetIntVal())
+		product := sum.Operands[1].GetArithmeticVal()
+		if assert.NotNil(t, product) {
+			assert.Equal(t, dsl.ArithmeticOperator_MULTIPLY, product.Operator)
+			assert.Equal(t, dsl.EcommerceOfferRule_Condition_AGE, product.Operands[0].GetFieldRef())
+		}
+	}
+	difference := rule.Actions[0].Value.GetArithmeticVal()
+	if assert.NotNil(t, difference) {
+		assert.Equal(t, dsl.ArithmeticOperator_SUBTRACT, difference.Operator)
+		assert.Len(t, difference.Operands, 3)
+	}
+
+	input = `rule Computed "Arithmetic on a string field" salience 1 {
+	when
+		Customer.Age > Customer.Location + 1
+	then
+		Offer.FreeShipping = true;
+}`
+
+	_, err = grl.ParseGRLToRuleEntity(input)
+	var coercion *grl.ValueCoercionError
+	if assert.ErrorAs(t, err, &coercion) {
+		assert.Equal(t, "Customer.Location", coercion.Literal)
+	}
+}
+
+func TestDecompileGRL_ActionOperators(t *testing.T) {
+	input := `rule Accumulate "Points add up across rules" salience 1 {
+	when
+		Customer.IsLoyaltyProgramMember == true
+	then
+		Offer.AddLoyaltyPoints += 10;
+		Offer.ApplyFlatDiscount = Helper.Max(Offer.ApplyFlatDiscount, Customer.CartTotal * 0.05);
+		Offer.ApplyDiscountPercent = Helper.Max(Customer.Age, 5);
+		Offer.PromoTags = Helper.Append(Offer.PromoTags, Customer.PreferredCategories);
+		Offer.AddLoyaltyPoints *= 2;
+		Retract("Accumulate");
+}`
+
+	rule, warnings, err := grl.DecompileGRL(input, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, rule.Actions, 4) {
+		assert.Equal(t, dsl.EcommerceOfferRule_Action_ADD, rule.Actions[0].Operator)
+		assert.Equal(t, int32(10), rule.Actions[0].Value.GetIntVal())
+		assert.Equal(t, dsl.EcommerceOfferRule_Action_MAX, rule.Actions[1].Operator)
+		assert.Equal(t, dsl.ArithmeticOperator_MULTIPLY, rule.Actions[1].Value.GetArithmeticVal().GetOperator())
+		// the maximum of other values is a plain assignment
+		assert.Equal(t, dsl.EcommerceOfferRule_Action_SET, rule.Actions[2].Operator)
+		assert.Equal(t, dsl.ArithmeticOperator_MAX, rule.Actions[2].Value.GetArithmeticVal().GetOperator())
+		assert.Equal(t, dsl.EcommerceOfferRule_Action_APPEND, rule.Actions[3].Operator)
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES, rule.Actions[3].Value.GetFieldRef())
+	}
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "unsupported assignment operator *=", warnings[0].Reason)
+	}
+}
+
+func TestParseGRLToRuleEntity_SchemaDirectedValueTypes(t *testing.T) {
+	input := `rule Typed "Values follow grl_field_type" salience 1 {
+	when
+		( Customer.Age > 30 ) && ( Customer.CartTotal >= 100 ) && ( Customer.ReturnRatePercent < 2.5 )
+	then
+		Offer.AddLoyaltyPoints = 50;
+		Offer.ApplyFlatDiscount = 5;
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(input)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	assert.Equal(t, &dsl.RuleValue_IntVal{IntVal: 30}, exprs[0].Value.Value)
+	assert.Equal(t, &dsl.RuleValue_FloatVal{FloatVal: 100}, exprs[1].Value.Value)
+	assert.Equal(t, &dsl.RuleValue_FloatVal{FloatVal: 2.5}, exprs[2].Value.Value)
+	assert.Equal(t, &dsl.RuleValue_IntVal{IntVal: 50}, rule.Actions[0].Value.Value)
+	assert.Equal(t, &dsl.RuleValue_FloatVal{FloatVal: 5}, rule.Actions[1].Value.Value)
+}
+
+func TestParseGRLToRuleEntity_ValueCoercionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		when     string
+		then     string
+		field    string
+		expected dsl.FieldType
+	}{
+		{"float literal on integer field", `Customer.Age > 30.5`, `Offer.FreeShipping = true`, "Customer.Age", dsl.FieldType_INTEGER},
+		{"string literal on bool field", `Customer.IsLoyaltyProgramMember == "yes"`, `Offer.FreeShipping = true`, "Customer.IsLoyaltyProgramMember", dsl.FieldType_BOOL},
+		{"number assigned to bool output", `Customer.Age > 30`, `Offer.FreeShipping = 10.00`, "Offer.FreeShipping", dsl.FieldType_BOOL},
+		{"number in category list", `Customer.HasCategory(Customer.BrowsingCategories, 7)`, `Offer.FreeShipping = true`, "Customer.BrowsingCategories", dsl.FieldType_STRING_LIST},
+		{"float arithmetic assigned to integer output", `Customer.Age > 30`, `Offer.AddLoyaltyPoints = Customer.CartTotal / 10`, "Offer.AddLoyaltyPoints", dsl.FieldType_INTEGER},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := `rule Coercion "bad literal" salience 1 {
+	when
+		` + tt.when + `
+	then
+		` + tt.then + `;
+}`
+			_, err := grl.ParseGRLToRuleEntity(input)
+			var coercionErr *grl.ValueCoercionError
+			if assert.ErrorAs(t, err, &coercionErr) {
+				assert.Equal(t, tt.field, coercionErr.Field)
+				assert.Equal(t, tt.expected, coercionErr.Expected)
+			}
+		})
+	}
+}
+
+const ruleWithUnknownConstructs = `rule Imported "From another team" salience 4 {
+	when
+		( Customer.CartTotal > 100.00 ) && ( Customer.Region == "EU" )
+	then
+		Offer.ApplyDiscountPercent = 15.00;
+		Offer.Note = "EU only";
+		Log("applied");
+		Retract("Imported");
+}`
+
+func TestDecompileGRL_LenientReportsWarnings(t *testing.T) {
+	rule, warnings, err := grl.DecompileGRL(ruleWithUnknownConstructs, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, rule.Conditions[0].Expressions, 1)
+	assert.Len(t, rule.Actions, 1)
+
+	if assert.Len(t, warnings, 3) {
+		assert.Equal(t, grl.Diagnostic{
+			Rule:   "Imported",
+			Pos:    grl.Pos{Line: 3, Column: 38},
+			Text:   `(Customer.Region == "EU")`,
+			Reason: "unknown input field Customer.Region",
+		}, warnings[0])
+		assert.Equal(t, grl.Pos{Line: 6, Column: 3}, warnings[1].Pos)
+		assert.Equal(t, "unknown output field Offer.Note", warnings[1].Reason)
+		assert.Equal(t, `Log("applied")`, warnings[2].Text)
+		assert.Equal(t, "unsupported statement", warnings[2].Reason)
+	}
+}
+
+func TestDecompileGRL_StrictFails(t *testing.T) {
+	rule, _, err := grl.DecompileGRL(ruleWithUnknownConstructs, grl.DecompileOptions{Strict: true})
+	assert.Nil(t, rule)
+	var diags grl.Diagnostics
+	if assert.ErrorAs(t, err, &diags) {
+		assert.Len(t, diags, 3)
+	}
+}
+
+func TestDecompileGRL_StrictAcceptsSerializerOutput(t *testing.T) {
+	rule, warnings, err := grl.DecompileGRL(`rule Clean "Nothing to drop" salience 1 {
+	when
+		( Customer.Age > 18 )
+	then
+		Offer.FreeShipping = true;
+		Retract("Clean");
+}`, grl.DecompileOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "Clean", rule.Name)
+}
+
+func TestParseGRLToRuleEntity_LossyFloatBecomesDecimal(t *testing.T) {
+	grlRule := `rule BigSpender "Large lifetime spend" salience 1 {
+	when
+		( Customer.AvgOrderValue > 12345678.91 ) && ( Customer.ReturnRatePercent < 2.375 )
+	then
+		Offer.ApplyFlatDiscount = 0.1;
+		Retract("BigSpender");
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(grlRule)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	assert.Equal(t, "12345678.91", exprs[0].Value.GetDecimalVal())
+	assert.Equal(t, float32(2.375), exprs[1].Value.GetFloatVal())
+	assert.Equal(t, float32(0.1), rule.Actions[0].Value.GetFloatVal())
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.AvgOrderValue > 12345678.91 ) && ( Customer.ReturnRatePercent < 2.375 )", entity.When)
+	assert.Equal(t, []string{"Offer.ApplyFlatDiscount = 0.10;"}, entity.Then)
+}
+
+func TestParseGRLToRuleEntity_LongAndDouble(t *testing.T) {
+	grlRule := `rule Enterprise "Enterprise customers" salience 1 {
+	when
+		( Customer.TotalSpent > 12345678.91 ) && ( Customer.LifetimeLoyaltyPoints >= 5000000000 ) && ( Customer.TotalSpent < Customer.LifetimeLoyaltyPoints * 2 )
+	then
+		Offer.FreeShipping = true;
+		Retract("Enterprise");
+}`
+
+	rule, err := grl.ParseGRLToRuleEntity(grlRule)
+	assert.NoError(t, err)
+	exprs := rule.Conditions[0].Expressions
+	if assert.Len(t, exprs, 3) {
+		assert.Equal(t, 12345678.91, exprs[0].Value.GetDoubleVal())
+		assert.Equal(t, int64(5000000000), exprs[1].Value.GetLongVal())
+		assert.Equal(t, dsl.EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS, exprs[2].Value.GetArithmeticVal().GetOperands()[0].GetFieldRef())
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.TotalSpent > 12345678.91 ) && ( Customer.LifetimeLoyaltyPoints >= 5000000000 ) && ( Customer.TotalSpent < Customer.LifetimeLoyaltyPoints * 2 )", entity.When)
+}
+
+func TestDecompileGRL_ConflictingTermination(t *testing.T) {
+	grlRule := `rule Conflicting "Retracts and completes" salience 1 {
+	when
+		( Customer.IsLoyaltyProgramMember == true )
+	then
+		Offer.FreeShipping = true;
+		Complete();
+		Retract("Conflicting");
+}`
+
+	rule, diags, err := grl.DecompileGRL(grlRule, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, dsl.GRuleTerminationMode_COMPLETE_ENGINE, rule.TerminationMode)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, `Retract("Conflicting")`, diags[0].Text)
+		assert.Equal(t, 7, diags[0].Pos.Line)
+	}
+}
+
+func TestDecompileGRL_MissingTermination(t *testing.T) {
+	grlRule := `rule Legacy "Hand-written" salience 1 {
+	when
+		( Customer.IsLoyaltyProgramMember == true )
+	then
+		Offer.FreeShipping = true;
+		Offer.AddLoyaltyPoints = 10;
+}`
+
+	rule, diags, err := grl.DecompileGRL(grlRule, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, dsl.GRuleTerminationMode_REFIRE, rule.TerminationMode)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "neither retracts the rule nor completes the engine", diags[0].Reason)
+		assert.Equal(t, 1, diags[0].Pos.Line)
+	}
+	_, _, err = grl.DecompileGRL(grlRule, grl.DecompileOptions{Strict: true})
+	var strictDiags grl.Diagnostics
+	assert.ErrorAs(t, err, &strictDiags)
+
+	refiring := strings.Replace(grlRule, "= 10;", "= 10;\n\t\tChanged(\"Offer.FreeShipping\");\n\t\tChanged(\"Offer.AddLoyaltyPoints\");", 1)
+	rule, diags, err = grl.DecompileGRL(refiring, grl.DecompileOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Empty(t, diags)
+	assert.Equal(t, dsl.GRuleTerminationMode_REFIRE, rule.TerminationMode)
+	assert.Len(t, rule.Actions, 2)
+}
+
+func TestDecompileGRL_ValidityWindow(t *testing.T) {
+	grlRule := `rule Spring "Spring campaign" salience 1 {
+	when
+		Customer.Age > 18 && Clock.Before("2026-06-01T00:00:00+02:00") && Customer.IsLoyaltyProgramMember == true && Clock.Before("2026-07-01T00:00:00Z")
+	then
+		Offer.FreeShipping = true;
+		Retract("Spring");
+}`
+
+	rule, warnings, err := grl.DecompileGRL(grlRule, grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, rule.ValidFrom)
+	assert.Equal(t, time.Date(2026, 5, 31, 22, 0, 0, 0, time.UTC), rule.ValidUntil.AsTime())
+	assert.Len(t, rule.Conditions[0].Expressions, 2)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "repeats the validity bound", warnings[0].Reason)
+	}
+
+	_, err = grl.ParseGRLToRuleEntity(`rule Broken "Invalid window" salience 1 {
+	when
+		Clock.NotBefore("spring") && Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+}`)
+	assert.ErrorContains(t, err, `Clock.NotBefore: parsing time "spring"`)
+
+	clockOnly := `rule Window "Window only" salience 1 {
+	when
+		Clock.NotBefore("2026-05-01T00:00:00Z") && Clock.Before("2026-06-01T00:00:00Z")
+	then
+		Offer.FreeShipping = true;
+}`
+	for _, opts := range []grl.DecompileOptions{{}, {Strict: true}} {
+		_, _, err = grl.DecompileGRL(clockOnly, opts)
+		assert.EqualError(t, err, "line 3:3: rule has no conditions besides its validity window")
+	}
+}
+
+func TestDecompileGRL_InvalidSchedule(t *testing.T) {
+	_, err := grl.ParseGRLToRuleEntity(`rule Evenings "Evening offer" salience 1 {
+	when
+		Clock.InSchedule("UTC", "FRIDAY", "evenings")
+	then
+		Offer.FreeShipping = true;
+}`)
+	assert.ErrorContains(t, err, `Clock.InSchedule: "evenings" is neither a weekday nor a valid time range`)
+
+	_, err = grl.ParseGRLToRuleEntity(`rule Evenings "Evening offer" salience 1 {
+	when
+		Clock.InSchedule("UTC", Customer.Age)
+	then
+		Offer.FreeShipping = true;
+}`)
+	assert.ErrorContains(t, err, "Clock.InSchedule expects string arguments, got Customer.Age")
+}
@@ -0,0 +1,290 @@
+package grl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"grule-protobuf-dsl/dsl"
+	"grule-protobuf-dsl/grl"
+)
+
+func TestParseGRLDocument_MultipleRules(t *testing.T) {
+	rules := []*dsl.EcommerceOfferRule{
+		{
+			Name:        "First",
+			Description: "Closing brace } in the description",
+			Salience:    1,
+			Conditions: []*dsl.EcommerceOfferRule_Condition{
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_AGE,
+							Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 21}},
+						},
+					},
+					ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+				},
+			},
+			Actions: []*dsl.EcommerceOfferRule_Action{
+				{
+					Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+					Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+				},
+			},
+		},
+		{
+			Name:        "Second",
+			Description: "Another rule",
+			Salience:    2,
+			Conditions: []*dsl.EcommerceOfferRule_Condition{
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+							Operator: dsl.GRuleExpressionOperator_EQUALS,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "Paris"}},
+						},
+					},
+					ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+				},
+			},
+			Actions: []*dsl.EcommerceOfferRule_Action{
+				{
+					Output: dsl.EcommerceOfferRule_Action_PROMO_MESSAGE,
+					Value:  &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "Bonjour"}},
+				},
+			},
+		},
+	}
+	entities := make([]*grl.GRuleEntity, 0, len(rules))
+	for _, rule := range rules {
+		entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+		assert.NoError(t, err)
+		entities = append(entities, entity)
+	}
+
+	document, err := grl.ToMultipleGRLs(entities)
+	assert.NoError(t, err)
+	parsed, err := grl.ParseGRLDocument(strings.NewReader(document))
+	assert.NoError(t, err)
+	if assert.Len(t, parsed, 2) {
+		assert.Equal(t, "First", parsed[0].Name)
+		assert.Equal(t, "Closing brace } in the description", parsed[0].Description)
+		assert.Equal(t, "Second", parsed[1].Name)
+		assert.Equal(t, "Bonjour", parsed[1].Actions[0].Value.GetStringVal())
+	}
+}
+
+func TestParseGRLDocument_ReportsFailuresPerRule(t *testing.T) {
+	input := `rule Good "Fine" salience 1 {
+	when
+		Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+}
+
+rule Broken "Missing semicolon" salience 2 {
+	when
+		Customer.Age > 30
+	then
+		Offer.FreeShipping = true
+}
+
+rule BadValue "Wrong type" salience 3 {
+	when
+		Customer.Age > "thirty"
+	then
+		Offer.FreeShipping = true;
+}
+
+rule Good "Declared twice" salience 4 {
+	when
+		Customer.Age > 40
+	then
+		Offer.FreeShipping = true;
+}
+
+rule AlsoGood "Still parsed after the failures" salience 5 {
+	when
+		Customer.CartTotal > 10.5
+	then
+		Offer.ApplyFlatDiscount = 1.5;
+}`
+
+	parsed, err := grl.ParseGRLDocument(strings.NewReader(input))
+	if assert.Len(t, parsed, 2) {
+		assert.Equal(t, "Good", parsed[0].Name)
+		assert.Equal(t, "AlsoGood", parsed[1].Name)
+	}
+
+	var docErr *grl.DocumentError
+	if assert.ErrorAs(t, err, &docErr) && assert.Len(t, docErr.Errors, 3) {
+		assert.Equal(t, "Broken", docErr.Errors[0].Rule)
+		assert.Equal(t, 8, docErr.Errors[0].Line)
+		var syntaxErr *grl.SyntaxError
+		assert.ErrorAs(t, docErr.Errors[0], &syntaxErr)
+		assert.Equal(t, 13, syntaxErr.Pos.Line)
+
+		assert.Equal(t, "BadValue", docErr.Errors[1].Rule)
+		assert.Equal(t, 15, docErr.Errors[1].Line)
+		var coercionErr *grl.ValueCoercionError
+		assert.ErrorAs(t, docErr.Errors[1], &coercionErr)
+
+		assert.Equal(t, "Good", docErr.Errors[2].Rule)
+		assert.Equal(t, 22, docErr.Errors[2].Line)
+	}
+}
+
+func TestParseGRLDocument_LexerErrorFailsOnlyItsRule(t *testing.T) {
+	input := `rule First "First rule" salience 1 {
+	when
+		Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+}
+
+rule Unterminated "Missing closing quote" salience 2 {
+	when
+		Customer.Location == "Rome
+	then
+		Offer.FreeShipping = true;
+}
+
+rule Third "Third rule" salience 3 {
+	when
+		Customer.Location == "Paris"
+	then
+		Offer.PromoMessage = "Bonjour";
+}`
+
+	parsed, err := grl.ParseGRLDocument(strings.NewReader(input))
+	if assert.Len(t, parsed, 2) {
+		assert.Equal(t, "First", parsed[0].Name)
+		assert.Equal(t, "Third", parsed[1].Name)
+		assert.Equal(t, "Bonjour", parsed[1].Actions[0].Value.GetStringVal())
+	}
+	var docErr *grl.DocumentError
+	if assert.ErrorAs(t, err, &docErr) && assert.Len(t, docErr.Errors, 1) {
+		assert.Equal(t, "Unterminated", docErr.Errors[0].Rule)
+		assert.Equal(t, 8, docErr.Errors[0].Line)
+		var syntaxErr *grl.SyntaxError
+		assert.ErrorAs(t, docErr.Errors[0], &syntaxErr)
+	}
+
+	input = `rule Stray "Stray character" salience 1 {
+	when
+		Customer.Age > 18 # adults
+	then
+		Offer.FreeShipping = true;
+}
+rule Last "Last rule" salience 2 {
+	when
+		Customer.Age > 40
+	then
+		Offer.FreeShipping = true;
+}`
+	parsed, err = grl.ParseGRLDocument(strings.NewReader(input))
+	if assert.Len(t, parsed, 1) {
+		assert.Equal(t, "Last", parsed[0].Name)
+	}
+	assert.EqualError(t, err, "1 rule(s) failed to decompile:\nrule Stray at line 1: line 3:21: unexpected character '#'")
+}
+
+func TestDecompileGRLDocument_Strict(t *testing.T) {
+	input := `rule Known "ok" salience 1 {
+	when
+		Customer.Age > 18
+	then
+		Offer.FreeShipping = true;
+		Retract("Known");
+}
+
+rule Unknown "drops a condition" salience 1 {
+	when
+		Customer.Age > 18 && Customer.Segment == "vip"
+	then
+		Offer.FreeShipping = true;
+		Retract("Unknown");
+}`
+
+	rules, warnings, err := grl.DecompileGRLDocument(strings.NewReader(input), grl.DecompileOptions{Strict: true})
+	assert.Len(t, rules, 1)
+	assert.Empty(t, warnings)
+	var docErr *grl.DocumentError
+	if assert.ErrorAs(t, err, &docErr) && assert.Len(t, docErr.Errors, 1) {
+		assert.Equal(t, "Unknown", docErr.Errors[0].Rule)
+		var diags grl.Diagnostics
+		assert.ErrorAs(t, docErr.Errors[0], &diags)
+	}
+
+	rules, warnings, err = grl.DecompileGRLDocument(strings.NewReader(input), grl.DecompileOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "Unknown", warnings[0].Rule)
+		assert.Equal(t, 11, warnings[0].Pos.Line)
+	}
+}
+
+func TestDecompileGRLDocument_Metadata(t *testing.T) {
+	input := `// Offers of the summer campaign
+// @owner_team: growth
+// @labels: summer
+rule First "First rule" salience 1 {
+	when
+		Customer.Age > 21
+	then
+		Offer.FreeShipping = true; // @owner_team: not metadata of Second
+		Retract("First");
+}
+
+/* @ticket: ignored, block comments are not metadata */
+//@ticket: MKT-7
+// @ticket: MKT-8
+// @reviewer: someone
+rule Second "Second rule" salience 2 {
+	when
+		Customer.Age > 30
+	then
+		Offer.FreeShipping = true;
+		Retract("Second");
+}
+
+rule Third "Third rule" salience 3 {
+	when
+		Customer.Age > 40
+	then
+		Offer.FreeShipping = true;
+		Retract("Third");
+}`
+
+	rules, warnings, err := grl.DecompileGRLDocument(strings.NewReader(input), grl.DecompileOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, rules, 3) {
+		assert.Equal(t, "growth", rules[0].Metadata.GetOwnerTeam())
+		assert.Equal(t, []string{"summer"}, rules[0].Metadata.GetLabels())
+		assert.Equal(t, "MKT-7", rules[1].Metadata.GetTicket())
+		assert.Empty(t, rules[1].Metadata.GetOwnerTeam())
+		assert.Nil(t, rules[2].Metadata)
+	}
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "rule Second line 14:1: repeats the metadata key: // @ticket: MKT-8", warnings[0].String())
+	}
+
+	rules, warnings, err = grl.DecompileGRLDocument(strings.NewReader(`// @reviewer: someone
+rule Reviewed "Unknown key" salience 1 {
+	when
+		Customer.Age > 21
+	then
+		Offer.FreeShipping = true;
+		Retract("Reviewed");
+}`), grl.DecompileOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	if assert.Len(t, rules, 1) {
+		assert.Nil(t, rules[0].Metadata)
+	}
+}
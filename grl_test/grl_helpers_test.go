@@ -0,0 +1,44 @@
+package grl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"grule-protobuf-dsl/grl"
+)
+
+func TestHelpers_In(t *testing.T) {
+	var h grl.Helpers
+	assert.True(t, h.In("Berlin", "Paris", "Berlin"))
+	assert.False(t, h.In("Rome", "Paris", "Berlin"))
+	assert.True(t, h.In(30, int64(20), int64(30)))
+	assert.True(t, h.In(float32(2.5), 2.5))
+	assert.True(t, h.In(float32(3), int64(3)))
+	assert.True(t, h.In(float32(0.1), 0.2, 0.1))
+	assert.False(t, h.In(30, "30"))
+	assert.False(t, h.In("Berlin"))
+}
+
+func TestHelpers_CountAndIsEmpty(t *testing.T) {
+	var h grl.Helpers
+	assert.Equal(t, 2, h.Count([]string{"a", "b"}))
+	assert.Equal(t, 0, h.Count([]string(nil)))
+	assert.Equal(t, 0, h.Count(nil))
+	assert.True(t, h.IsEmpty([]string{}))
+	assert.False(t, h.IsEmpty([]string{"a"}))
+}
+
+func TestHelpers_MinAndMax(t *testing.T) {
+	var h grl.Helpers
+	assert.Equal(t, 15.0, h.Min(30, int64(15), 20.5))
+	assert.Equal(t, 30.0, h.Max(30, int64(15), float32(20.5)))
+	assert.Panics(t, func() { h.Max(1, "2") })
+}
+
+func TestHelpers_Append(t *testing.T) {
+	var h grl.Helpers
+	assert.Equal(t, []string{"a", "b", "c"}, h.Append([]string{"a"}, "b", "c"))
+	assert.Equal(t, []string{"a", "x", "y"}, h.Append([]string{"a"}, []string{"x", "y"}))
+	assert.Equal(t, []string{"b"}, h.Append([]string(nil), "b"))
+}
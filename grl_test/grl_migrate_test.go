@@ -0,0 +1,117 @@
+package grl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"grule-protobuf-dsl/dsl"
+	"grule-protobuf-dsl/grl"
+)
+
+func TestMigrateListValues(t *testing.T) {
+	comma := func(list string) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: list}}
+	}
+	tests := []struct {
+		name     string
+		input    dsl.EcommerceOfferRule_Condition_InputField
+		operator dsl.GRuleExpressionOperator
+		value    *dsl.RuleValue
+		expected *dsl.RuleValue
+	}{
+		{
+			name:     "categories",
+			input:    dsl.EcommerceOfferRule_Condition_BROWSING_CATEGORIES,
+			operator: dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION,
+			value:    comma("Books, ,Toys"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"Books", "Toys"}}}},
+		},
+		{
+			name:     "string candidates",
+			input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+			operator: dsl.GRuleExpressionOperator_IN,
+			value:    comma("Berlin,Paris"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"Berlin", "Paris"}}}},
+		},
+		{
+			name:     "integer candidates",
+			input:    dsl.EcommerceOfferRule_Condition_AGE,
+			operator: dsl.GRuleExpressionOperator_NOT_IN,
+			value:    comma("30, 40"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_IntList{IntList: &dsl.IntList{Values: []int32{30, 40}}}},
+		},
+		{
+			name:     "float candidates",
+			input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+			operator: dsl.GRuleExpressionOperator_IN,
+			value:    comma("19.99,100"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_FloatList{FloatList: &dsl.FloatList{Values: []float32{19.99, 100}}}},
+		},
+		{
+			name:     "long candidates",
+			input:    dsl.EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS,
+			operator: dsl.GRuleExpressionOperator_IN,
+			value:    comma("1000,5000000000"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_LongList{LongList: &dsl.LongList{Values: []int64{1000, 5000000000}}}},
+		},
+		{
+			name:     "double candidates",
+			input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+			operator: dsl.GRuleExpressionOperator_NOT_IN,
+			value:    comma("100.5, 123456789.25"),
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_DoubleList{DoubleList: &dsl.DoubleList{Values: []float64{100.5, 123456789.25}}}},
+		},
+		{
+			name:     "invalid candidates are kept",
+			input:    dsl.EcommerceOfferRule_Condition_AGE,
+			operator: dsl.GRuleExpressionOperator_IN,
+			value:    comma("30,forty"),
+			expected: comma("30,forty"),
+		},
+		{
+			name:     "typed lists are kept",
+			input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+			operator: dsl.GRuleExpressionOperator_IN,
+			value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"a,b"}}}},
+			expected: &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"a,b"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr := &dsl.EcommerceOfferRule_Condition_Expression{Input: tt.input, Operator: tt.operator, Value: tt.value}
+			rule := &dsl.EcommerceOfferRule{
+				ConditionTree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Not{
+					Not: &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: expr}},
+				}},
+			}
+			grl.MigrateListValues(rule)
+			assert.True(t, proto.Equal(tt.expected, expr.Value), "got %v", expr.Value)
+		})
+	}
+}
+
+func TestMigrateListValues_Actions(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+				{
+					Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+					Operator: dsl.GRuleExpressionOperator_EQUALS,
+					Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "Berlin,Paris"}},
+				},
+			}},
+		},
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output:   dsl.EcommerceOfferRule_Action_PROMO_TAGS,
+				Operator: dsl.EcommerceOfferRule_Action_APPEND,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "loyal,vip"}},
+			},
+		},
+	}
+	grl.MigrateListValues(rule)
+	assert.Equal(t, "Berlin,Paris", rule.Conditions[0].Expressions[0].Value.GetStringVal())
+	assert.Equal(t, []string{"loyal", "vip"}, rule.Actions[0].Value.GetStringList().GetValues())
+}
@@ -1,9 +1,13 @@
 package grl_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"grule-protobuf-dsl/dsl"
 	"grule-protobuf-dsl/grl"
@@ -348,3 +352,1657 @@ func TestEcommerceOfferRuleToGRuleEntity_EmptyStringList(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, `HAS_CATEGORY_FUNCTION used with empty list for field Customer.CartContainsCategories`, err.Error())
 }
+
+func TestEcommerceOfferRuleToGRuleEntity_TypeMismatch(t *testing.T) {
+	validCondition := &dsl.EcommerceOfferRule_Condition{
+		Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+			{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+			},
+		},
+		ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+	}
+	validAction := &dsl.EcommerceOfferRule_Action{
+		Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+		Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []*dsl.EcommerceOfferRule_Condition
+		actions    []*dsl.EcommerceOfferRule_Action
+		expected   *grl.TypeMismatchError
+	}{
+		{
+			name: "string compared with bool field",
+			conditions: []*dsl.EcommerceOfferRule_Condition{
+				validCondition,
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						validCondition.Expressions[0],
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+							Operator: dsl.GRuleExpressionOperator_EQUALS,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "yes"}},
+						},
+					},
+					ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+				},
+			},
+			actions: []*dsl.EcommerceOfferRule_Action{validAction},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "conditions[1].expressions[1]",
+				Field:    "Customer.IsLoyaltyProgramMember",
+				Expected: dsl.FieldType_BOOL,
+				Actual:   dsl.ValueType_STRING_VAL,
+			},
+		},
+		{
+			name:       "float assigned to bool output",
+			conditions: []*dsl.EcommerceOfferRule_Condition{validCondition},
+			actions: []*dsl.EcommerceOfferRule_Action{
+				validAction,
+				{
+					Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+					Value:  &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 10}},
+				},
+			},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "actions[1]",
+				Field:    "Offer.FreeShipping",
+				Expected: dsl.FieldType_BOOL,
+				Actual:   dsl.ValueType_FLOAT_VAL,
+			},
+		},
+		{
+			name:       "float compared with integer field",
+			conditions: []*dsl.EcommerceOfferRule_Condition{{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{{Input: dsl.EcommerceOfferRule_Condition_AGE, Operator: dsl.GRuleExpressionOperator_GREATER_THAN, Value: &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 17.5}}}}}},
+			actions:    []*dsl.EcommerceOfferRule_Action{validAction},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "conditions[0].expressions[0]",
+				Field:    "Customer.Age",
+				Expected: dsl.FieldType_INTEGER,
+				Actual:   dsl.ValueType_FLOAT_VAL,
+			},
+		},
+		{
+			name:       "integer field compared with float field",
+			conditions: []*dsl.EcommerceOfferRule_Condition{{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{{Input: dsl.EcommerceOfferRule_Condition_AGE, Operator: dsl.GRuleExpressionOperator_GREATER_THAN, Value: &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: dsl.EcommerceOfferRule_Condition_CART_TOTAL}}}}}},
+			actions:    []*dsl.EcommerceOfferRule_Action{validAction},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "conditions[0].expressions[0]",
+				Field:    "Customer.Age",
+				Expected: dsl.FieldType_INTEGER,
+				Actual:   dsl.ValueType_FIELD_REF_VAL,
+				Ref:      "Customer.CartTotal",
+				RefType:  dsl.FieldType_FLOAT,
+			},
+		},
+		{
+			name:       "long compared with integer field",
+			conditions: []*dsl.EcommerceOfferRule_Condition{{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{{Input: dsl.EcommerceOfferRule_Condition_AGE, Operator: dsl.GRuleExpressionOperator_GREATER_THAN, Value: &dsl.RuleValue{Value: &dsl.RuleValue_LongVal{LongVal: 18}}}}}},
+			actions:    []*dsl.EcommerceOfferRule_Action{validAction},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "conditions[0].expressions[0]",
+				Field:    "Customer.Age",
+				Expected: dsl.FieldType_INTEGER,
+				Actual:   dsl.ValueType_LONG_VAL,
+			},
+		},
+		{
+			name:       "double compared with float field",
+			conditions: []*dsl.EcommerceOfferRule_Condition{{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{{Input: dsl.EcommerceOfferRule_Condition_CART_TOTAL, Operator: dsl.GRuleExpressionOperator_GREATER_THAN, Value: &dsl.RuleValue{Value: &dsl.RuleValue_DoubleVal{DoubleVal: 100}}}}}},
+			actions:    []*dsl.EcommerceOfferRule_Action{validAction},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "conditions[0].expressions[0]",
+				Field:    "Customer.CartTotal",
+				Expected: dsl.FieldType_FLOAT,
+				Actual:   dsl.ValueType_DOUBLE_VAL,
+			},
+		},
+		{
+			name:       "missing value",
+			conditions: []*dsl.EcommerceOfferRule_Condition{validCondition},
+			actions:    []*dsl.EcommerceOfferRule_Action{{Output: dsl.EcommerceOfferRule_Action_PROMO_MESSAGE}},
+			expected: &grl.TypeMismatchError{
+				Rule:     "Mismatch",
+				Path:     "actions[0]",
+				Field:    "Offer.PromoMessage",
+				Expected: dsl.FieldType_STRING,
+				Actual:   dsl.ValueType_VALUE_TYPE_UNSPECIFIED,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := grl.EcommerceOfferRuleToGRuleEntity(&dsl.EcommerceOfferRule{
+				Name:                  "Mismatch",
+				Salience:              1,
+				Conditions:            tt.conditions,
+				ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+				Actions:               tt.actions,
+			})
+			var mismatch *grl.TypeMismatchError
+			if assert.ErrorAs(t, err, &mismatch) {
+				assert.Equal(t, tt.expected, mismatch)
+			}
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_IntegerForFloatField(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Name:     "IntegerForFloat",
+		Salience: 1,
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 100}},
+					},
+				},
+			},
+		},
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 5}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Contains(t, entity.When, "Customer.CartTotal > 100")
+	assert.Contains(t, entity.Then[0], "Offer.ApplyFlatDiscount = 5;")
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_LongAndDouble(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Name:     "Enterprise",
+		Salience: 1,
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_DoubleVal{DoubleVal: 123456789.25}},
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS,
+						Operator: dsl.GRuleExpressionOperator_BETWEEN,
+						Value: &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+							Lower: &dsl.RuleValue{Value: &dsl.RuleValue_LongVal{LongVal: 5000000000}},
+							Upper: &dsl.RuleValue{Value: &dsl.RuleValue_LongVal{LongVal: 9000000000}},
+						}}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+		},
+		ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.TotalSpent > 123456789.25 ) && ( Customer.LifetimeLoyaltyPoints >= 5000000000 && Customer.LifetimeLoyaltyPoints <= 9000000000 )", entity.When)
+
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+
+	rule.Conditions[0].Expressions[0].Value = &dsl.RuleValue{Value: &dsl.RuleValue_DoubleVal{DoubleVal: 0.125}}
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.EqualError(t, err, "rule Enterprise: conditions[0].expressions[0]: 0.125 has more than 2 decimal places allowed for Customer.TotalSpent")
+}
+
+func TestToGRL_EscapesDescription(t *testing.T) {
+	entity := &grl.GRuleEntity{
+		Name:        "QuotedDescription",
+		Description: "Say \"hi\"\nthen Offer.FreeShipping = true;",
+		Salience:    "1",
+		When:        "( Customer.IsPremium == true )",
+		Then:        []string{"Offer.PromoMessage = \"Hello\";"},
+	}
+
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	assert.Contains(t, text, `rule QuotedDescription "Say \"hi\"\nthen Offer.FreeShipping = true;" salience 1 {`)
+
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Description, decompiled.Description)
+	assert.Len(t, decompiled.Actions, 1)
+}
+
+func TestToGRL_InvalidRuleName(t *testing.T) {
+	for _, name := range []string{"", "Summer Sale", "summer-sale", "10PercentOff", "_hidden", "when", "Rule"} {
+		_, err := grl.ToGRL(&grl.GRuleEntity{Name: name, Salience: "1", When: "true", Then: []string{"Offer.FreeShipping = true;"}})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestToGRL_InvalidSalience(t *testing.T) {
+	_, err := grl.ToGRL(&grl.GRuleEntity{Name: "BadSalience", Salience: "1 { ", When: "true", Then: []string{"Offer.FreeShipping = true;"}})
+	assert.EqualError(t, err, `rule BadSalience: invalid salience "1 { "`)
+}
+
+func TestSanitizeRuleName(t *testing.T) {
+	cases := map[string]string{
+		"SummerSale":         "SummerSale",
+		"Summer Sale - 2025": "Summer_Sale_2025",
+		"10% off!":           "R_10_off",
+		"  leading space":    "leading_space",
+		"when":               "when_",
+		"café":               "caf",
+		"":                   "R_",
+	}
+	for in, want := range cases {
+		got := grl.SanitizeRuleName(in)
+		assert.Equal(t, want, got, in)
+		assert.NoError(t, grl.ValidateRuleName(got), in)
+		assert.Equal(t, got, grl.SanitizeRuleName(got), in)
+	}
+}
+
+func TestToMultipleGRLs_DuplicateName(t *testing.T) {
+	entity := &grl.GRuleEntity{Name: "Twice", Salience: "1", When: "true", Then: []string{"Offer.FreeShipping = true;"}}
+	_, err := grl.ToMultipleGRLs([]*grl.GRuleEntity{entity, entity})
+	assert.EqualError(t, err, "duplicate rule name Twice")
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ExactFloats(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Name:     "ExactFloats",
+		Salience: 1,
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_RETURN_RATE_PERCENT,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 2.375}},
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 99.5}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+		},
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_APPLY_DISCOUNT_PERCENT,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 12.5}},
+			},
+			{
+				Output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: "12345678.9"}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.ReturnRatePercent > 2.375 ) && ( Customer.CartTotal >= 99.50 )", entity.When)
+	assert.Equal(t, []string{
+		"Offer.ApplyDiscountPercent = 12.50;",
+		"Offer.ApplyFlatDiscount = 12345678.90;",
+	}, entity.Then)
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_DecimalRoundTrip(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Name:     "Decimals",
+		Salience: 1,
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: "12345678.91"}},
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+						Operator: dsl.GRuleExpressionOperator_LESS_THAN,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_DecimalVal{DecimalVal: "12345678901234567.89"}},
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+		},
+		ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_APPLY_DISCOUNT_PERCENT,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 10}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.CartTotal > 12345678.91 ) && ( Customer.TotalSpent < 12345678901234567.89 )", entity.When)
+	assert.Equal(t, []string{"Offer.ApplyDiscountPercent = 10.00;"}, entity.Then)
+
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_PrecisionExceeded(t *testing.T) {
+	for _, val := range []*dsl.RuleValue{
+		{Value: &dsl.RuleValue_FloatVal{FloatVal: 19.999}},
+		{Value: &dsl.RuleValue_DecimalVal{DecimalVal: "19.999"}},
+		{Value: &dsl.RuleValue_DecimalVal{DecimalVal: "1e3"}},
+	} {
+		rule := &dsl.EcommerceOfferRule{
+			Name: "TooPrecise",
+			Conditions: []*dsl.EcommerceOfferRule_Condition{
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+							Operator: dsl.GRuleExpressionOperator_EQUALS,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+						},
+					},
+				},
+			},
+			Actions: []*dsl.EcommerceOfferRule_Action{
+				{Output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT, Value: val},
+			},
+		}
+
+		_, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+		var precisionErr *grl.PrecisionError
+		if assert.ErrorAs(t, err, &precisionErr) {
+			assert.Equal(t, "actions[0]", precisionErr.Path)
+			assert.Equal(t, "Offer.ApplyFlatDiscount", precisionErr.Field)
+		}
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_TerminationModes(t *testing.T) {
+	newRule := func(mode dsl.GRuleTerminationMode) *dsl.EcommerceOfferRule {
+		return &dsl.EcommerceOfferRule{
+			Name:     "Terminating",
+			Salience: 1,
+			Conditions: []*dsl.EcommerceOfferRule_Condition{
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+							Operator: dsl.GRuleExpressionOperator_EQUALS,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+						},
+					},
+				},
+			},
+			Actions: []*dsl.EcommerceOfferRule_Action{
+				{
+					Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+					Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+				},
+			},
+			TerminationMode: mode,
+		}
+	}
+
+	cases := []struct {
+		mode        dsl.GRuleTerminationMode
+		termination string
+		then        string
+	}{
+		{dsl.GRuleTerminationMode_RETRACT_SELF, grl.TerminationRetractSelf, "Offer.FreeShipping = true;\n\t\tRetract(\"Terminating\");\n}"},
+		{dsl.GRuleTerminationMode_REFIRE, grl.TerminationRefire, "Offer.FreeShipping = true;\n\t\tChanged(\"Offer.FreeShipping\");\n}"},
+		{dsl.GRuleTerminationMode_COMPLETE_ENGINE, grl.TerminationCompleteEngine, "Offer.FreeShipping = true;\n\t\tComplete();\n}"},
+	}
+	for _, c := range cases {
+		t.Run(c.mode.String(), func(t *testing.T) {
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(newRule(c.mode))
+			assert.NoError(t, err)
+			assert.Equal(t, c.termination, entity.Termination)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(text, c.then), text)
+
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.Equal(t, c.mode, decompiled.TerminationMode)
+			assert.Len(t, decompiled.Actions, 1)
+		})
+	}
+}
+
+func TestToGRL_UnknownTermination(t *testing.T) {
+	_, err := grl.ToGRL(&grl.GRuleEntity{Name: "Odd", Salience: "1", When: "true", Then: []string{"Offer.FreeShipping = true;"}, Termination: "sometimes"})
+	assert.EqualError(t, err, `rule Odd: unknown termination "sometimes"`)
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ConditionTree(t *testing.T) {
+	leaf := func(input dsl.EcommerceOfferRule_Condition_InputField, op dsl.GRuleExpressionOperator, val *dsl.RuleValue) *dsl.ConditionNode {
+		return &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: &dsl.EcommerceOfferRule_Condition_Expression{
+			Input: input, Operator: op, Value: val,
+		}}}
+	}
+	group := func(op dsl.GRuleJoinOperator, children ...*dsl.ConditionNode) *dsl.ConditionNode {
+		return &dsl.ConditionNode{Node: &dsl.ConditionNode_Group_{Group: &dsl.ConditionNode_Group{Operator: op, Children: children}}}
+	}
+	rule := &dsl.EcommerceOfferRule{
+		Name:     "LoyalBigBasketsLowReturns",
+		Salience: 3,
+		ConditionTree: group(dsl.GRuleJoinOperator_AND,
+			group(dsl.GRuleJoinOperator_AND,
+				leaf(dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER, dsl.GRuleExpressionOperator_EQUALS,
+					&dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}}),
+				group(dsl.GRuleJoinOperator_OR,
+					leaf(dsl.EcommerceOfferRule_Condition_CART_TOTAL, dsl.GRuleExpressionOperator_GREATER_THAN,
+						&dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 500}}),
+					leaf(dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS, dsl.GRuleExpressionOperator_GREATER_THAN,
+						&dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 3}}),
+				),
+			),
+			&dsl.ConditionNode{Node: &dsl.ConditionNode_Not{Not: leaf(dsl.EcommerceOfferRule_Condition_RETURN_RATE_PERCENT,
+				dsl.GRuleExpressionOperator_GREATER_THAN, &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 20}})}},
+		),
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, `( ( Customer.IsLoyaltyProgramMember == true ) && ( ( Customer.CartTotal > 500.00 ) || ( Customer.PurchaseCount30d > 3 ) ) ) && !( Customer.ReturnRatePercent > 20.0 )`, entity.When)
+
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ConditionTreeErrors(t *testing.T) {
+	expr := &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_AGE,
+		Operator: dsl.GRuleExpressionOperator_EQUALS,
+		Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "thirty"}},
+	}
+	actions := []*dsl.EcommerceOfferRule_Action{
+		{
+			Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+			Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+		},
+	}
+
+	_, err := grl.EcommerceOfferRuleToGRuleEntity(&dsl.EcommerceOfferRule{
+		Name: "Both",
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{expr}},
+		},
+		ConditionTree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: expr}},
+		Actions:       actions,
+	})
+	assert.EqualError(t, err, "rule Both: conditions and condition_tree cannot both be set")
+
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(&dsl.EcommerceOfferRule{
+		Name: "NegatedMismatch",
+		ConditionTree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Group_{Group: &dsl.ConditionNode_Group{
+			Operator: dsl.GRuleJoinOperator_OR,
+			Children: []*dsl.ConditionNode{
+				{Node: &dsl.ConditionNode_Group_{Group: &dsl.ConditionNode_Group{}}},
+				{Node: &dsl.ConditionNode_Not{Not: &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: expr}}}},
+			},
+		}}},
+		Actions: actions,
+	})
+	assert.EqualError(t, err, "rule NegatedMismatch: condition_tree.group.children[0].group: empty condition group")
+
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(&dsl.EcommerceOfferRule{
+		Name: "NegatedMismatch",
+		ConditionTree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Not{
+			Not: &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: expr}},
+		}},
+		Actions: actions,
+	})
+	var mismatch *grl.TypeMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, "condition_tree.not.expression", mismatch.Path)
+	}
+}
+
+func TestConditionTree_ConvertsFlatConditions(t *testing.T) {
+	expr := func(age int32) *dsl.EcommerceOfferRule_Condition_Expression {
+		return &dsl.EcommerceOfferRule_Condition_Expression{
+			Input:    dsl.EcommerceOfferRule_Condition_AGE,
+			Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+			Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: age}},
+		}
+	}
+	rule := &dsl.EcommerceOfferRule{
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{expr(1), expr(2)}, ExpressionJoinOperator: dsl.GRuleJoinOperator_OR},
+			{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{expr(3)}},
+		},
+		ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+	}
+
+	tree, err := grl.ConditionTree(rule)
+	assert.NoError(t, err)
+	root := tree.GetGroup()
+	assert.Equal(t, dsl.GRuleJoinOperator_AND, root.Operator)
+	if assert.Len(t, root.Children, 2) {
+		assert.Equal(t, dsl.GRuleJoinOperator_OR, root.Children[0].GetGroup().Operator)
+		assert.Len(t, root.Children[0].GetGroup().Children, 2)
+		assert.Equal(t, int32(3), root.Children[1].GetGroup().Children[0].GetExpression().Value.GetIntVal())
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_StringOperators(t *testing.T) {
+	tests := []struct {
+		operator dsl.GRuleExpressionOperator
+		value    string
+		when     string
+	}{
+		{dsl.GRuleExpressionOperator_CONTAINS, "erl", `( Customer.Location.Contains("erl") )`},
+		{dsl.GRuleExpressionOperator_STARTS_WITH, "Ber", `( Customer.Location.HasPrefix("Ber") )`},
+		{dsl.GRuleExpressionOperator_ENDS_WITH, "lin", `( Customer.Location.HasSuffix("lin") )`},
+		{dsl.GRuleExpressionOperator_MATCHES_REGEX, `^B\w+n$`, `( Customer.Location.MatchString("^B\\w+n$") )`},
+		{dsl.GRuleExpressionOperator_EQUALS_IGNORE_CASE, "BERLIN", `( Customer.Location.ToLower() == "berlin" )`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operator.String(), func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "StringOperator",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{
+						Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+							{
+								Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+								Operator: tt.operator,
+								Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: tt.value}},
+							},
+						},
+					},
+				},
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			expr := decompiled.Conditions[0].Expressions[0]
+			assert.Equal(t, tt.operator, expr.Operator)
+			assert.Equal(t, dsl.EcommerceOfferRule_Condition_LOCATION, expr.Input)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_InvalidRegex(t *testing.T) {
+	rule := &dsl.EcommerceOfferRule{
+		Name: "BrokenPattern",
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_DEVICE_TYPE,
+						Operator: dsl.GRuleExpressionOperator_MATCHES_REGEX,
+						Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "(iphone|ipad"}},
+					},
+				},
+			},
+		},
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+			},
+		},
+	}
+
+	_, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	var patternErr *grl.PatternError
+	if assert.ErrorAs(t, err, &patternErr) {
+		assert.Equal(t, "conditions[0].expressions[0]", patternErr.Path)
+		assert.Equal(t, "(iphone|ipad", patternErr.Pattern)
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_SetOperators(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  *dsl.EcommerceOfferRule_Condition_Expression
+		when  string
+		error string
+	}{
+		{
+			name: "string in",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "Berlin, Paris"}},
+			},
+			when: `( Helper.In(Customer.Location, "Berlin", "Paris") )`,
+		},
+		{
+			name: "integer not in",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_NOT_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "30,40"}},
+			},
+			when: `( !Helper.In(Customer.Age, 30, 40) )`,
+		},
+		{
+			name: "integer candidate is not a number",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "30,forty"}},
+			},
+			error: `rule Membership: conditions[0].expressions[0]: candidate "forty" is not a valid INTEGER for Customer.Age`,
+		},
+		{
+			name: "single value",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "Berlin"}},
+			},
+			error: "rule Membership: conditions[0].expressions[0]: Customer.Location expects a STRING_LIST value, got STRING_VAL",
+		},
+		{
+			name: "empty list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: " , "}},
+			},
+			error: "IN used with empty list for field Customer.Location",
+		},
+		{
+			name: "string list elements containing commas",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LAST_CATEGORY_PURCHASED,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"Toys, Games & Puzzles", "Books"}}}},
+			},
+			when: `( Helper.In(Customer.LastCategoryPurchased, "Toys, Games & Puzzles", "Books") )`,
+		},
+		{
+			name: "string list keeps empty elements",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"", "Berlin"}}}},
+			},
+			when: `( Helper.In(Customer.Location, "", "Berlin") )`,
+		},
+		{
+			name: "int list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_NOT_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntList{IntList: &dsl.IntList{Values: []int32{30, 40}}}},
+			},
+			when: `( !Helper.In(Customer.Age, 30, 40) )`,
+		},
+		{
+			name: "float list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatList{FloatList: &dsl.FloatList{Values: []float32{19.99, 100}}}},
+			},
+			when: `( Helper.In(Customer.CartTotal, 19.99, 100.00) )`,
+		},
+		{
+			name: "double list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_DoubleList{DoubleList: &dsl.DoubleList{Values: []float64{100.5, 123456789.25}}}},
+			},
+			when: `( Helper.In(Customer.TotalSpent, 100.50, 123456789.25) )`,
+		},
+		{
+			name: "double candidates",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+				Operator: dsl.GRuleExpressionOperator_NOT_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "0, 99.9"}},
+			},
+			when: `( !Helper.In(Customer.TotalSpent, 0.00, 99.90) )`,
+		},
+		{
+			name: "long list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LIFETIME_LOYALTY_POINTS,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_LongList{LongList: &dsl.LongList{Values: []int64{1000, 5000000000}}}},
+			},
+			when: `( Helper.In(Customer.LifetimeLoyaltyPoints, 1000, 5000000000) )`,
+		},
+		{
+			name: "float list beyond precision",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatList{FloatList: &dsl.FloatList{Values: []float32{19.99, 19.999}}}},
+			},
+			error: "rule Membership: conditions[0].expressions[0].float_list.values[1]: 19.999 has more than 2 decimal places allowed for Customer.CartTotal",
+		},
+		{
+			name: "list of another type",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntList{IntList: &dsl.IntList{Values: []int32{1}}}},
+			},
+			error: "rule Membership: conditions[0].expressions[0]: INT_LIST_VAL candidates cannot be compared with STRING field Customer.Location",
+		},
+		{
+			name: "empty string list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_IN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{}}},
+			},
+			error: "IN used with empty list for field Customer.Location",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "Membership",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{tt.expr}},
+				},
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			// comma concatenated candidates come back as the list they migrate to
+			grl.MigrateListValues(rule)
+			assert.True(t, proto.Equal(rule.Conditions[0].Expressions[0], decompiled.Conditions[0].Expressions[0]), "got %v", decompiled.Conditions[0].Expressions[0])
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ListAggregates(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  *dsl.EcommerceOfferRule_Condition_Expression
+		when  string
+		error string
+	}{
+		{
+			name: "count",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:     dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES,
+				Aggregate: dsl.GRuleAggregate_COUNT,
+				Operator:  dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS,
+				Value:     &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 3}},
+			},
+			when: "( Helper.Count(Customer.PreferredCategories) >= 3 )",
+		},
+		{
+			name: "is empty",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_CONTAINS_CATEGORIES,
+				Operator: dsl.GRuleExpressionOperator_IS_EMPTY,
+			},
+			when: "( Helper.IsEmpty(Customer.CartContainsCategories) )",
+		},
+		{
+			name: "is not empty",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_CONTAINS_CATEGORIES,
+				Operator: dsl.GRuleExpressionOperator_IS_NOT_EMPTY,
+			},
+			when: "( !Helper.IsEmpty(Customer.CartContainsCategories) )",
+		},
+		{
+			name: "count compared with a float",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:     dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES,
+				Aggregate: dsl.GRuleAggregate_COUNT,
+				Operator:  dsl.GRuleExpressionOperator_GREATER_THAN,
+				Value:     &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 2.5}},
+			},
+			error: "rule Aggregates: conditions[0].expressions[0]: Customer.PreferredCategories expects a INTEGER value, got FLOAT_VAL",
+		},
+		{
+			name: "count of a scalar field",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:     dsl.EcommerceOfferRule_Condition_LOCATION,
+				Aggregate: dsl.GRuleAggregate_COUNT,
+				Operator:  dsl.GRuleExpressionOperator_GREATER_THAN,
+				Value:     &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 2}},
+			},
+			error: "rule Aggregates: conditions[0].expressions[0]: aggregate COUNT cannot be applied to STRING field Customer.Location",
+		},
+		{
+			name: "count with a template operator",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:     dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES,
+				Aggregate: dsl.GRuleAggregate_COUNT,
+				Operator:  dsl.GRuleExpressionOperator_IN,
+				Value:     &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "1,2"}},
+			},
+			error: "rule Aggregates: conditions[0].expressions[0]: aggregate COUNT cannot be used with operator IN",
+		},
+		{
+			name: "is empty with a value",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_CONTAINS_CATEGORIES,
+				Operator: dsl.GRuleExpressionOperator_IS_EMPTY,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+			},
+			error: "rule Aggregates: conditions[0].expressions[0]: operator IS_EMPTY takes no value",
+		},
+		{
+			name: "relational operator on a list",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES,
+				Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 2}},
+			},
+			error: "rule Aggregates: conditions[0].expressions[0]: operator GREATER_THAN cannot be applied to STRING_LIST field Customer.PreferredCategories",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "Aggregates",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{tt.expr}},
+				},
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			rule.Conditions[0].ExpressionJoinOperator = dsl.GRuleJoinOperator_AND
+			rule.ConditionJoinOperator = dsl.GRuleJoinOperator_AND
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_RangeOperators(t *testing.T) {
+	ageRange := func(lower, upper int32, lowerExclusive, upperExclusive bool) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+			Lower:          &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: lower}},
+			Upper:          &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: upper}},
+			LowerExclusive: lowerExclusive,
+			UpperExclusive: upperExclusive,
+		}}}
+	}
+	tests := []struct {
+		name  string
+		expr  *dsl.EcommerceOfferRule_Condition_Expression
+		when  string
+		error string
+	}{
+		{
+			name: "between",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value:    ageRange(18, 25, false, true),
+			},
+			when: "( Customer.Age >= 18 && Customer.Age < 25 )",
+		},
+		{
+			name: "not between",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_NOT_BETWEEN,
+				Value:    ageRange(18, 25, true, false),
+			},
+			when: "( Customer.Age <= 18 || Customer.Age > 25 )",
+		},
+		{
+			name: "money tier",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value: &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+					Lower:          &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 100}},
+					Upper:          &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 499.99}},
+					UpperExclusive: true,
+				}}},
+			},
+			when: "( Customer.CartTotal >= 100.00 && Customer.CartTotal < 499.99 )",
+		},
+		{
+			name: "count between",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:     dsl.EcommerceOfferRule_Condition_PREFERRED_CATEGORIES,
+				Aggregate: dsl.GRuleAggregate_COUNT,
+				Operator:  dsl.GRuleExpressionOperator_BETWEEN,
+				Value:     ageRange(1, 3, false, false),
+			},
+			when: "( Helper.Count(Customer.PreferredCategories) >= 1 && Helper.Count(Customer.PreferredCategories) <= 3 )",
+		},
+		{
+			name: "empty range",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value:    ageRange(25, 25, false, true),
+			},
+			error: "rule Ranges: conditions[0].expressions[0]: range of Customer.Age is empty",
+		},
+		{
+			name: "missing bound",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value: &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+					Lower: &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+				}}},
+			},
+			error: "rule Ranges: conditions[0].expressions[0].range_val.upper: missing upper bound",
+		},
+		{
+			name: "bound of the wrong type",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value: &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+					Lower: &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 17.5}},
+					Upper: &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 25}},
+				}}},
+			},
+			error: "rule Ranges: conditions[0].expressions[0].range_val.lower: Customer.Age expects a INTEGER value, got FLOAT_VAL",
+		},
+		{
+			name: "single value",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_AGE,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+			},
+			error: "rule Ranges: conditions[0].expressions[0]: operator BETWEEN expects a RANGE_VAL value, got INTEGER_VAL",
+		},
+		{
+			name: "string field",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+				Operator: dsl.GRuleExpressionOperator_BETWEEN,
+				Value:    ageRange(1, 2, false, false),
+			},
+			error: "rule Ranges: conditions[0].expressions[0]: operator BETWEEN cannot be applied to STRING field Customer.Location",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "Ranges",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{tt.expr}},
+				},
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			rule.Conditions[0].ExpressionJoinOperator = dsl.GRuleJoinOperator_AND
+			rule.ConditionJoinOperator = dsl.GRuleJoinOperator_AND
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_FieldReferences(t *testing.T) {
+	ref := func(input dsl.EcommerceOfferRule_Condition_InputField) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: input}}
+	}
+	rule := &dsl.EcommerceOfferRule{
+		Name: "AboveAverageBasket",
+		Conditions: []*dsl.EcommerceOfferRule_Condition{
+			{
+				Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+						Value:    ref(dsl.EcommerceOfferRule_Condition_AVG_ORDER_VALUE),
+					},
+					{
+						Input:    dsl.EcommerceOfferRule_Condition_TOTAL_LIFETIME_SPENT,
+						Operator: dsl.GRuleExpressionOperator_GREATER_THAN_EQUALS,
+						Value:    ref(dsl.EcommerceOfferRule_Condition_SIGNUP_DAYS_AGO),
+					},
+				},
+				ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+			},
+		},
+		ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+		Actions: []*dsl.EcommerceOfferRule_Action{
+			{
+				Output: dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+				Value:  ref(dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS),
+			},
+		},
+	}
+
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.NoError(t, err)
+	assert.Equal(t, "( Customer.CartTotal > Customer.AvgOrderValue ) && ( Customer.TotalSpent >= Customer.SignupDaysAgo )", entity.When)
+	assert.Equal(t, []string{"Offer.AddLoyaltyPoints = Customer.PurchaseCount30d;"}, entity.Then)
+
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+
+	rule.Conditions[0].Expressions[0] = &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_LOCATION,
+		Operator: dsl.GRuleExpressionOperator_STARTS_WITH,
+		Value:    ref(dsl.EcommerceOfferRule_Condition_GENDER),
+	}
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.EqualError(t, err, "rule AboveAverageBasket: conditions[0].expressions[0]: operator STARTS_WITH cannot be used with a field reference")
+
+	rule.Conditions[0].Expressions[0] = &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_AGE,
+		Operator: dsl.GRuleExpressionOperator_BETWEEN,
+		Value: &dsl.RuleValue{Value: &dsl.RuleValue_RangeVal{RangeVal: &dsl.Range{
+			Lower: &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+			Upper: ref(dsl.EcommerceOfferRule_Condition_SIGNUP_DAYS_AGO),
+		}}},
+	}
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule)
+	assert.EqualError(t, err, "rule AboveAverageBasket: conditions[0].expressions[0].range_val.upper: range bounds must be literals")
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_Arithmetic(t *testing.T) {
+	ref := func(input dsl.EcommerceOfferRule_Condition_InputField) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: input}}
+	}
+	num := func(i int32) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: i}}
+	}
+	calc := func(op dsl.ArithmeticOperator, operands ...*dsl.RuleValue) *dsl.RuleValue {
+		return &dsl.RuleValue{Value: &dsl.RuleValue_ArithmeticVal{ArithmeticVal: &dsl.Arithmetic{Operator: op, Operands: operands}}}
+	}
+	tests := []struct {
+		name   string
+		output dsl.EcommerceOfferRule_Action_OutputField
+		value  *dsl.RuleValue
+		then   string
+		error  string
+	}{
+		{
+			name:   "percentage of the cart",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value:  calc(dsl.ArithmeticOperator_MULTIPLY, ref(dsl.EcommerceOfferRule_Condition_CART_TOTAL), &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 0.05}}),
+			then:   "Offer.ApplyFlatDiscount = Customer.CartTotal * 0.05;",
+		},
+		{
+			name:   "integer points",
+			output: dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+			value:  calc(dsl.ArithmeticOperator_MULTIPLY, ref(dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS), num(10)),
+			then:   "Offer.AddLoyaltyPoints = Customer.PurchaseCount30d * 10;",
+		},
+		{
+			name:   "nested operations keep their grouping",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value: calc(dsl.ArithmeticOperator_MULTIPLY,
+				calc(dsl.ArithmeticOperator_SUBTRACT, num(100), ref(dsl.EcommerceOfferRule_Condition_AGE)),
+				calc(dsl.ArithmeticOperator_MAX, ref(dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS), num(1))),
+			then: "Offer.ApplyFlatDiscount = ( 100 - Customer.Age ) * Helper.Max(Customer.PurchaseCount30d, 1);",
+		},
+		{
+			name:   "float result assigned to an integer field",
+			output: dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+			value:  calc(dsl.ArithmeticOperator_DIVIDE, ref(dsl.EcommerceOfferRule_Condition_CART_TOTAL), num(10)),
+			error:  "rule Computed: actions[0]: Offer.AddLoyaltyPoints expects a INTEGER value, got FLOAT arithmetic",
+		},
+		{
+			name:   "maximum of integers is a float",
+			output: dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+			value:  calc(dsl.ArithmeticOperator_MAX, ref(dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS), num(1)),
+			error:  "rule Computed: actions[0]: Offer.AddLoyaltyPoints expects a INTEGER value, got FLOAT arithmetic",
+		},
+		{
+			name:   "assigned to a string field",
+			output: dsl.EcommerceOfferRule_Action_PROMO_MESSAGE,
+			value:  calc(dsl.ArithmeticOperator_ADD, ref(dsl.EcommerceOfferRule_Condition_AGE), num(1)),
+			error:  "rule Computed: actions[0]: Offer.PromoMessage expects a STRING value, got INTEGER arithmetic",
+		},
+		{
+			name:   "string operand",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value:  calc(dsl.ArithmeticOperator_ADD, ref(dsl.EcommerceOfferRule_Condition_LOCATION), num(1)),
+			error:  "rule Computed: actions[0].arithmetic_val.operands[0]: STRING field Customer.Location cannot be used in arithmetic",
+		},
+		{
+			name:   "single operand",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value:  calc(dsl.ArithmeticOperator_MIN, num(1)),
+			error:  "rule Computed: actions[0].arithmetic_val: MIN needs at least two operands",
+		},
+		{
+			name:   "unspecified operator",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value:  calc(dsl.ArithmeticOperator_ARITHMETIC_OPERATOR_UNSPECIFIED, num(1), num(2)),
+			error:  "rule Computed: actions[0].arithmetic_val: unsupported arithmetic operator ARITHMETIC_OPERATOR_UNSPECIFIED",
+		},
+		{
+			name:   "unknown operator",
+			output: dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+			value:  calc(dsl.ArithmeticOperator(42), num(1), num(2)),
+			error:  "rule Computed: actions[0].arithmetic_val: unsupported arithmetic operator 42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "Computed",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{
+						Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+							{
+								Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+								Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+								Value: calc(dsl.ArithmeticOperator_MULTIPLY,
+									ref(dsl.EcommerceOfferRule_Condition_AVG_ORDER_VALUE), &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 1.5}}),
+							},
+						},
+						ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+					},
+				},
+				ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+				Actions:               []*dsl.EcommerceOfferRule_Action{{Output: tt.output, Value: tt.value}},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "( Customer.CartTotal > Customer.AvgOrderValue * 1.5 )", entity.When)
+			assert.Equal(t, []string{tt.then}, entity.Then)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ActionOperators(t *testing.T) {
+	tests := []struct {
+		name   string
+		action *dsl.EcommerceOfferRule_Action
+		then   string
+		error  string
+	}{
+		{
+			name: "add",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+				Operator: dsl.EcommerceOfferRule_Action_ADD,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 50}},
+			},
+			then: "Offer.AddLoyaltyPoints += 50;",
+		},
+		{
+			name: "subtract",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_APPLY_DISCOUNT_PERCENT,
+				Operator: dsl.EcommerceOfferRule_Action_SUBTRACT,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 2.5}},
+			},
+			then: "Offer.ApplyDiscountPercent -= 2.50;",
+		},
+		{
+			name: "max",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_APPLY_FLAT_DISCOUNT,
+				Operator: dsl.EcommerceOfferRule_Action_MAX,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FloatVal{FloatVal: 20}},
+			},
+			then: "Offer.ApplyFlatDiscount = Helper.Max(Offer.ApplyFlatDiscount, 20.00);",
+		},
+		{
+			name: "min of a field",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_ADD_LOYALTY_POINTS,
+				Operator: dsl.EcommerceOfferRule_Action_MIN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_FieldRef{FieldRef: dsl.EcommerceOfferRule_Condition_PURCHASE_COUNT_LAST_30_DAYS}},
+			},
+			then: "Offer.AddLoyaltyPoints = Helper.Min(Offer.AddLoyaltyPoints, Customer.PurchaseCount30d);",
+		},
+		{
+			name: "append",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_PROMO_TAGS,
+				Operator: dsl.EcommerceOfferRule_Action_APPEND,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringList{StringList: &dsl.StringList{Values: []string{"loyal", "vip"}}}},
+			},
+			then: `Offer.PromoTags = Helper.Append(Offer.PromoTags, "loyal", "vip");`,
+		},
+		{
+			name: "add to a string",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_PROMO_MESSAGE,
+				Operator: dsl.EcommerceOfferRule_Action_ADD,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "!"}},
+			},
+			error: "rule Accumulate: actions[0]: action operator ADD cannot be applied to STRING field Offer.PromoMessage",
+		},
+		{
+			name: "set a list",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output: dsl.EcommerceOfferRule_Action_PROMO_TAGS,
+				Value:  &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "loyal"}},
+			},
+			error: "rule Accumulate: actions[0]: action operator SET cannot be applied to STRING_LIST field Offer.PromoTags",
+		},
+		{
+			name: "append a single string",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_PROMO_TAGS,
+				Operator: dsl.EcommerceOfferRule_Action_APPEND,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "loyal"}},
+			},
+			error: "rule Accumulate: actions[0]: Offer.PromoTags expects a STRING_LIST value, got STRING_VAL",
+		},
+		{
+			name: "append an empty list",
+			action: &dsl.EcommerceOfferRule_Action{
+				Output:   dsl.EcommerceOfferRule_Action_PROMO_TAGS,
+				Operator: dsl.EcommerceOfferRule_Action_APPEND,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: ""}},
+			},
+			error: "APPEND used with empty list for field Offer.PromoTags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name: "Accumulate",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{
+					{
+						Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+							{
+								Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+								Operator: dsl.GRuleExpressionOperator_EQUALS,
+								Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+							},
+						},
+						ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+					},
+				},
+				ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+				Actions:               []*dsl.EcommerceOfferRule_Action{tt.action},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.then}, entity.Then)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_ValidityWindow(t *testing.T) {
+	from := timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	until := timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	age := &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_AGE,
+		Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+		Value:    &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 18}},
+	}
+	member := &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+		Operator: dsl.GRuleExpressionOperator_EQUALS,
+		Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+	}
+	tests := []struct {
+		name  string
+		from  *timestamppb.Timestamp
+		until *timestamppb.Timestamp
+		exprs []*dsl.EcommerceOfferRule_Condition_Expression
+		join  dsl.GRuleJoinOperator
+		when  string
+		error string
+	}{
+		{
+			name:  "both bounds",
+			from:  from,
+			until: until,
+			exprs: []*dsl.EcommerceOfferRule_Condition_Expression{age, member},
+			join:  dsl.GRuleJoinOperator_AND,
+			when:  `( Clock.NotBefore("2026-01-01T00:00:00Z") ) && ( Clock.Before("2026-02-01T00:00:00Z") ) && ( ( Customer.Age > 18 ) && ( Customer.IsLoyaltyProgramMember == true ) )`,
+		},
+		{
+			name:  "alternatives",
+			from:  from,
+			exprs: []*dsl.EcommerceOfferRule_Condition_Expression{age, member},
+			join:  dsl.GRuleJoinOperator_OR,
+			when:  `( Clock.NotBefore("2026-01-01T00:00:00Z") ) && ( ( Customer.Age > 18 ) || ( Customer.IsLoyaltyProgramMember == true ) )`,
+		},
+		{
+			name:  "single expression",
+			until: timestamppb.New(time.Date(2026, 2, 1, 8, 30, 0, 500, time.FixedZone("CET", 3600))),
+			exprs: []*dsl.EcommerceOfferRule_Condition_Expression{age},
+			join:  dsl.GRuleJoinOperator_AND,
+			when:  `( Clock.Before("2026-02-01T07:30:00.0000005Z") ) && ( Customer.Age > 18 )`,
+		},
+		{
+			name:  "empty window",
+			from:  until,
+			until: from,
+			exprs: []*dsl.EcommerceOfferRule_Condition_Expression{age},
+			error: "rule Window: valid_from 2026-02-01T00:00:00Z is not before valid_until 2026-01-01T00:00:00Z",
+		},
+		{
+			name:  "invalid timestamp",
+			from:  &timestamppb.Timestamp{Seconds: 1, Nanos: -1},
+			exprs: []*dsl.EcommerceOfferRule_Condition_Expression{age},
+			// the protobuf error text is not stable, only its location is checked
+			error: "rule Window: valid_from: proto:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name:                  "Window",
+				Salience:              1,
+				Conditions:            []*dsl.EcommerceOfferRule_Condition{{Expressions: tt.exprs, ExpressionJoinOperator: tt.join}},
+				ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+				ValidFrom:  tt.from,
+				ValidUntil: tt.until,
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_Schedule(t *testing.T) {
+	schedule := func(s *dsl.Schedule) *dsl.ConditionNode {
+		return &dsl.ConditionNode{Node: &dsl.ConditionNode_Schedule{Schedule: s}}
+	}
+	member := &dsl.ConditionNode{Node: &dsl.ConditionNode_Expression{Expression: &dsl.EcommerceOfferRule_Condition_Expression{
+		Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+		Operator: dsl.GRuleExpressionOperator_EQUALS,
+		Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+	}}}
+	happyHour := &dsl.Schedule{
+		Days:       []dsl.Weekday{dsl.Weekday_FRIDAY},
+		TimeRanges: []*dsl.Schedule_TimeRange{{Start: "18:00", End: "21:00"}},
+		TimeZone:   "Europe/Berlin",
+	}
+	tests := []struct {
+		name  string
+		tree  *dsl.ConditionNode
+		when  string
+		error string
+	}{
+		{
+			name: "happy hour",
+			tree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Group_{Group: &dsl.ConditionNode_Group{
+				Operator: dsl.GRuleJoinOperator_AND,
+				Children: []*dsl.ConditionNode{schedule(happyHour), member},
+			}}},
+			when: `( Clock.InSchedule("Europe/Berlin", "FRIDAY", "18:00-21:00") ) && ( Customer.IsLoyaltyProgramMember == true )`,
+		},
+		{
+			name: "weekend",
+			tree: schedule(&dsl.Schedule{Days: []dsl.Weekday{dsl.Weekday_SATURDAY, dsl.Weekday_SUNDAY}}),
+			when: `( Clock.InSchedule("", "SATURDAY", "SUNDAY") )`,
+		},
+		{
+			name: "outside the schedule",
+			tree: &dsl.ConditionNode{Node: &dsl.ConditionNode_Not{Not: schedule(&dsl.Schedule{
+				TimeRanges: []*dsl.Schedule_TimeRange{{Start: "00:00", End: "06:00"}, {Start: "22:00", End: "24:00"}},
+				TimeZone:   "America/New_York",
+			})}},
+			when: `!( Clock.InSchedule("America/New_York", "00:00-06:00", "22:00-24:00") )`,
+		},
+		{
+			name:  "empty",
+			tree:  schedule(&dsl.Schedule{TimeZone: "UTC"}),
+			error: "rule Schedule: condition_tree.schedule: schedule has neither days nor time ranges",
+		},
+		{
+			name:  "unspecified weekday",
+			tree:  schedule(&dsl.Schedule{Days: []dsl.Weekday{dsl.Weekday_MONDAY, dsl.Weekday_WEEKDAY_UNSPECIFIED}}),
+			error: "rule Schedule: condition_tree.schedule.days[1]: unsupported weekday WEEKDAY_UNSPECIFIED",
+		},
+		{
+			name:  "backwards range",
+			tree:  schedule(&dsl.Schedule{TimeRanges: []*dsl.Schedule_TimeRange{{Start: "21:00", End: "18:00"}}}),
+			error: "rule Schedule: condition_tree.schedule.time_ranges[0]: time range 21:00-18:00 ends before it starts",
+		},
+		{
+			name:  "invalid time",
+			tree:  schedule(&dsl.Schedule{TimeRanges: []*dsl.Schedule_TimeRange{{Start: "6pm", End: "21:00"}}}),
+			error: `rule Schedule: condition_tree.schedule.time_ranges[0]: invalid time of day "6pm"`,
+		},
+		{
+			name:  "unknown zone",
+			tree:  schedule(&dsl.Schedule{Days: []dsl.Weekday{dsl.Weekday_FRIDAY}, TimeZone: "Europe/Atlantis"}),
+			error: `rule Schedule: condition_tree.schedule.time_zone: unknown time zone "Europe/Atlantis"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &dsl.EcommerceOfferRule{
+				Name:          "Schedule",
+				Salience:      1,
+				ConditionTree: tt.tree,
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			}
+
+			entity, err := grl.EcommerceOfferRuleToGRuleEntity(rule)
+			if tt.error != "" {
+				assert.EqualError(t, err, tt.error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.when, entity.When)
+
+			text, err := grl.ToGRL(entity)
+			assert.NoError(t, err)
+			decompiled, err := grl.ParseGRLToRuleEntity(text)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(rule, decompiled), "got %v", decompiled)
+		})
+	}
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_Metadata(t *testing.T) {
+	rule := func(metadata *dsl.RuleMetadata) *dsl.EcommerceOfferRule {
+		return &dsl.EcommerceOfferRule{
+			Name:     "HappyHour",
+			Salience: 1,
+			Conditions: []*dsl.EcommerceOfferRule_Condition{
+				{
+					Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{
+						{
+							Input:    dsl.EcommerceOfferRule_Condition_IS_LOYALTY_PROGRAM_MEMBER,
+							Operator: dsl.GRuleExpressionOperator_EQUALS,
+							Value:    &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+						},
+					},
+					ExpressionJoinOperator: dsl.GRuleJoinOperator_AND,
+				},
+			},
+			ConditionJoinOperator: dsl.GRuleJoinOperator_AND,
+			Actions: []*dsl.EcommerceOfferRule_Action{
+				{
+					Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+					Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+				},
+			},
+			Metadata: metadata,
+		}
+	}
+
+	original := rule(&dsl.RuleMetadata{
+		OwnerTeam: "growth",
+		Ticket:    "MKT-1234",
+		CreatedBy: "j.doe@example.com",
+		Labels:    []string{"happy-hour", "loyalty: members"},
+		Notes:     "Agreed with finance.\n\nReview after the summer.",
+	})
+	entity, err := grl.EcommerceOfferRuleToGRuleEntity(original)
+	assert.NoError(t, err)
+	text, err := grl.ToGRL(entity)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(text, `// @owner_team: growth
+// @ticket: MKT-1234
+// @created_by: j.doe@example.com
+// @labels: happy-hour
+// @labels: loyalty: members
+// @notes: Agreed with finance.
+// @notes:
+// @notes: Review after the summer.
+rule HappyHour "" salience 1 {`), text)
+
+	decompiled, err := grl.ParseGRLToRuleEntity(text)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(original, decompiled), "got %v", decompiled)
+
+	entity, err = grl.EcommerceOfferRuleToGRuleEntity(rule(nil))
+	assert.NoError(t, err)
+	assert.Empty(t, entity.Comments)
+
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule(&dsl.RuleMetadata{Ticket: "MKT-1\nrule Injected"}))
+	assert.EqualError(t, err, "rule HappyHour: metadata.ticket: contains a line break")
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule(&dsl.RuleMetadata{Labels: []string{"summer", " "}}))
+	assert.EqualError(t, err, "rule HappyHour: metadata.labels[1]: empty label")
+	_, err = grl.EcommerceOfferRuleToGRuleEntity(rule(&dsl.RuleMetadata{Notes: "one\r\ntwo"}))
+	assert.EqualError(t, err, "rule HappyHour: metadata.notes: contains a carriage return")
+	_, err = grl.ToGRL(&grl.GRuleEntity{Name: "Comment", Salience: "1", When: "true", Then: []string{"Offer.FreeShipping = true;"},
+		Comments: []string{"first\nsecond"}})
+	assert.EqualError(t, err, `rule Comment: comment "first\nsecond" spans several lines`)
+}
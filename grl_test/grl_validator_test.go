@@ -0,0 +1,97 @@
+package grl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"grule-protobuf-dsl/dsl"
+	"grule-protobuf-dsl/grl"
+)
+
+func TestIsOperatorApplicable(t *testing.T) {
+	assert.True(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_GREATER_THAN, dsl.FieldType_FLOAT))
+	assert.True(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_EQUALS, dsl.FieldType_STRING))
+	assert.True(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION, dsl.FieldType_STRING_LIST))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_GREATER_THAN, dsl.FieldType_STRING))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION, dsl.FieldType_FLOAT))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_EQUALS, dsl.FieldType_STRING_LIST))
+	assert.True(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_CONTAINS, dsl.FieldType_STRING))
+	assert.True(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_HAS_ALL_CATEGORIES, dsl.FieldType_STRING_LIST))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_HAS_NO_CATEGORIES, dsl.FieldType_STRING))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_MATCHES_REGEX, dsl.FieldType_INTEGER))
+	assert.False(t, grl.IsOperatorApplicable(dsl.GRuleExpressionOperator_EQUALS_IGNORE_CASE, dsl.FieldType_STRING_LIST))
+	assert.Empty(t, grl.OperandFieldTypes(dsl.GRuleExpressionOperator_EXPRESSION_OPERATOR_UNSPECIFIED))
+}
+
+func TestEcommerceOfferRuleToGRuleEntity_OperatorMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     *dsl.EcommerceOfferRule_Condition_Expression
+		expected *grl.OperatorMismatchError
+	}{
+		{
+			name: "relational operator on string field",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_GENDER,
+				Operator: dsl.GRuleExpressionOperator_GREATER_THAN,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringVal{StringVal: "F"}},
+			},
+			expected: &grl.OperatorMismatchError{
+				Rule:      "Matrix",
+				Path:      "conditions[0].expressions[0]",
+				Field:     "Customer.Gender",
+				FieldType: dsl.FieldType_STRING,
+				Operator:  dsl.GRuleExpressionOperator_GREATER_THAN,
+			},
+		},
+		{
+			name: "category function on float field",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input:    dsl.EcommerceOfferRule_Condition_CART_TOTAL,
+				Operator: dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION,
+				Value:    &dsl.RuleValue{Value: &dsl.RuleValue_StringListCommaConcatenated{StringListCommaConcatenated: "Books"}},
+			},
+			expected: &grl.OperatorMismatchError{
+				Rule:      "Matrix",
+				Path:      "conditions[0].expressions[0]",
+				Field:     "Customer.CartTotal",
+				FieldType: dsl.FieldType_FLOAT,
+				Operator:  dsl.GRuleExpressionOperator_HAS_CATEGORY_FUNCTION,
+			},
+		},
+		{
+			name: "unspecified operator",
+			expr: &dsl.EcommerceOfferRule_Condition_Expression{
+				Input: dsl.EcommerceOfferRule_Condition_AGE,
+				Value: &dsl.RuleValue{Value: &dsl.RuleValue_IntVal{IntVal: 3}},
+			},
+			expected: &grl.OperatorMismatchError{
+				Rule:      "Matrix",
+				Path:      "conditions[0].expressions[0]",
+				Field:     "Customer.Age",
+				FieldType: dsl.FieldType_INTEGER,
+				Operator:  dsl.GRuleExpressionOperator_EXPRESSION_OPERATOR_UNSPECIFIED,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := grl.EcommerceOfferRuleToGRuleEntity(&dsl.EcommerceOfferRule{
+				Name:       "Matrix",
+				Conditions: []*dsl.EcommerceOfferRule_Condition{{Expressions: []*dsl.EcommerceOfferRule_Condition_Expression{tt.expr}}},
+				Actions: []*dsl.EcommerceOfferRule_Action{
+					{
+						Output: dsl.EcommerceOfferRule_Action_FREE_SHIPPING,
+						Value:  &dsl.RuleValue{Value: &dsl.RuleValue_BoolVal{BoolVal: true}},
+					},
+				},
+			})
+			var mismatch *grl.OperatorMismatchError
+			if assert.ErrorAs(t, err, &mismatch) {
+				assert.Equal(t, tt.expected, mismatch)
+			}
+		})
+	}
+}
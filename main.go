@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/hyperjumptech/grule-rule-engine/ast"
 	"github.com/hyperjumptech/grule-rule-engine/builder"
@@ -22,7 +23,7 @@ type Customer struct {
 	Location                string
 	DeviceType              string
 	IsLoyaltyProgramMember  bool
-	TotalSpent              float32
+	TotalSpent              float64
 	AvgOrderValue           float32
 	LastPurchaseDaysAgo     int
 	LastCategoryPurchased   string
@@ -34,6 +35,7 @@ type Customer struct {
 	ReturnRatePercent       float32
 	HasRedeemedCouponBefore bool
 	SignupDaysAgo           int
+	LifetimeLoyaltyPoints   int64
 }
 
 func (c Customer) HasCategory(field []string, categories ...string) bool {
@@ -47,6 +49,19 @@ func (c Customer) HasCategory(field []string, categories ...string) bool {
 	return false
 }
 
+func (c Customer) HasAllCategories(field []string, categories ...string) bool {
+	for _, input := range categories {
+		if !c.HasCategory(field, input) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Customer) HasNoCategories(field []string, categories ...string) bool {
+	return !c.HasCategory(field, categories...)
+}
+
 type Offer struct {
 	ApplyDiscountPercent float32
 	ApplyFlatDiscount    float32
@@ -55,6 +70,7 @@ type Offer struct {
 	AssignCoupon         string
 	PromoMessage         string
 	AddLoyaltyPoints     int
+	PromoTags            []string
 }
 
 type RuleContext struct {
@@ -77,6 +93,10 @@ func loadAllRulesFromDir(dir string) ([]*dsl.EcommerceOfferRule, error) {
 			DiscardUnknown: true,
 			AllowPartial:   true,
 		}.Unmarshal(data, &rule)
+		grl.MigrateListValues(&rule)
+		if grl.IsExpired(&rule, time.Now()) {
+			fmt.Fprintf(os.Stderr, "rule %s in %s expired at %s\n", rule.Name, path, rule.ValidUntil.AsTime().Format(time.RFC3339))
+		}
 		rules = append(rules, &rule)
 		return nil
 	})
@@ -120,6 +140,14 @@ func exampleOne() (ast.IDataContext, *RuleContext, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	err = grl.AddHelpers(dc)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = grl.AddClock(dc, grl.NewClock(time.Now))
+	if err != nil {
+		return nil, nil, err
+	}
 	return dc, ruleCtx, nil
 }
 
@@ -143,6 +171,14 @@ func exampleTwo() (ast.IDataContext, *RuleContext, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	err = grl.AddHelpers(dc)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = grl.AddClock(dc, grl.NewClock(time.Now))
+	if err != nil {
+		return nil, nil, err
+	}
 	return dc, ruleCtx, nil
 }
 
@@ -160,8 +196,12 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
-		grlRules = append(grlRules, grl.ToGRL(entity))
-		fmt.Println("Loaded GRule:\n", grl.ToGRL(entity))
+		grlRule, err := grl.ToGRL(entity)
+		if err != nil {
+			panic(err)
+		}
+		grlRules = append(grlRules, grlRule)
+		fmt.Println("Loaded GRule:\n", grlRule)
 	}
 
 	// Step 3: Load into engine and create context